@@ -0,0 +1,80 @@
+package timewalk
+
+import (
+	"strings"
+	"time"
+)
+
+// Period is the span business days are counted in.
+type Period int
+
+const (
+	PeriodMonth Period = iota
+	PeriodQuarter
+)
+
+func (p Period) String() string {
+	if p == PeriodQuarter {
+		return "quarter"
+	}
+	return "month"
+}
+
+// isBusinessDay reports whether date is a working day. Without calendars
+// Saturday and Sunday are the only days off.
+func (s *Schedule) isBusinessDay(date time.Time) bool {
+	if len(s.Calendars) == 0 {
+		return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+	}
+	return !s.isHoliday(date)
+}
+
+// businessDayPool returns the days of month whose business day ordinal
+// within BusinessDayPeriod matches BusinessDayField.
+func (s *Schedule) businessDayPool(year int, month time.Month) []int {
+	first, last := month, month
+	if s.BusinessDayPeriod == PeriodQuarter {
+		first = (month-1)/3*3 + 1
+		last = first + 2
+	}
+	type businessDay struct {
+		month time.Month
+		day   int
+	}
+	days := make([]businessDay, 0)
+	for m := first; m <= last; m++ {
+		for d := 1; d <= maxDay(year, m); d++ {
+			if s.isBusinessDay(time.Date(year, m, d, 0, 0, 0, 0, s.Loc)) {
+				days = append(days, businessDay{m, d})
+			}
+		}
+	}
+	pool := make([]int, 0)
+	for i, v := range days {
+		n := i + 1
+		if s.BusinessDayFromEnd {
+			n = len(days) - i
+		}
+		if v.month == month && s.BusinessDayField.Match(n) {
+			pool = append(pool, v.day)
+		}
+	}
+	return pool
+}
+
+func (s *Schedule) businessDayString() string {
+	b := strings.Builder{}
+	f := s.BusinessDayField
+	if s.BusinessDayFromEnd && len(f) == 1 && f[0].Type == TValue && *f[0].Value == 1 {
+		b.WriteString("at last business day")
+	} else if s.BusinessDayFromEnd {
+		b.WriteString(f.String("business day from the end"))
+	} else {
+		b.WriteString(f.String("business day"))
+	}
+	if s.BusinessDayPeriod != PeriodMonth {
+		b.WriteString(" of ")
+		b.WriteString(s.BusinessDayPeriod.String())
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedule_BusinessDay(t *testing.T) {
+	// 2024-05-01 is a Wednesday and a holiday
+	c := NewCalendar("vn").WithWeekend(time.Saturday, time.Sunday).
+		AddHoliday(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+	s := Scheduler().WithLoc(time.UTC).WithCalendar(c).BusinessDay(At(3)).Hour(At(9)).Minute(At(0)).Second(At(0))
+	may := time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, &may, s.Next(time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 6, 5, 9, 0, 0, 0, time.UTC)), s.NextAfter(may))
+	assert.Equal(t, ptr(time.Date(2024, 4, 3, 9, 0, 0, 0, time.UTC)), s.PreviousBefore(may))
+	assert.Equal(t, "at 3rd business day, at 9th hour, at 0th minute, at 0th second, skipping holidays", s.String())
+
+	// last business day, 2024-08-31 is a Saturday
+	s.LastBusinessDay(At(1))
+	aug := time.Date(2024, 8, 30, 9, 0, 0, 0, time.UTC)
+	assert.Equal(t, &aug, s.Next(time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, &aug, s.Previous(time.Date(2024, 9, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "at last business day, at 9th hour, at 0th minute, at 0th second, skipping holidays", s.String())
+	s.LastBusinessDay(At(2))
+	assert.Equal(t, "at 2nd business day from the end, at 9th hour, at 0th minute, at 0th second, skipping holidays", s.String())
+
+	// with day of week
+	s.BusinessDay(From(1).To(10)).DayOfWeek(At(time.Friday))
+	assert.Equal(t, ptr(time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)), s.Next(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 6, 7, 9, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC)))
+}
+
+func TestSchedule_BusinessDayOfQuarter(t *testing.T) {
+	// without calendars only weekends are days off
+	s := Scheduler().WithLoc(time.UTC).BusinessDayOf(PeriodQuarter).LastBusinessDay(At(1)).
+		Hour(At(17)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 3, 29, 17, 0, 0, 0, time.UTC)), s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 6, 28, 17, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 3, 29, 17, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2023, 12, 29, 17, 0, 0, 0, time.UTC)), s.Previous(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "at last business day of quarter, at 17th hour, at 0th minute, at 0th second", s.String())
+
+	s.BusinessDay(At(1))
+	assert.Equal(t, ptr(time.Date(2024, 4, 1, 17, 0, 0, 0, time.UTC)), s.Next(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,370 @@
+package timewalk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout   = "2006-01-02"
+	annualLayout = "01-02"
+)
+
+// Calendar reports which dates are not business days.
+type Calendar interface {
+	IsHoliday(date time.Time) bool
+}
+
+// MemoryCalendar is a Calendar backed by a list of weekend days, fixed
+// dates and dates recurring every year.
+type MemoryCalendar struct {
+	Name     string         `json:"name"`
+	Weekend  []time.Weekday `json:"weekend"`
+	Holidays []string       `json:"holidays"` // 2006-01-02
+	Annual   []string       `json:"annual"`   // 01-02
+	Feasts   []Feast        `json:"feasts"`
+}
+
+func NewCalendar(name string) *MemoryCalendar {
+	return &MemoryCalendar{
+		Name: name,
+	}
+}
+
+func CalendarFromJSON(data string) (*MemoryCalendar, error) {
+	var c *MemoryCalendar
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		return nil, err
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *MemoryCalendar) validate() error {
+	for _, v := range c.Holidays {
+		if _, err := time.Parse(dateLayout, v); err != nil {
+			return err
+		}
+	}
+	for _, v := range c.Annual {
+		if _, err := time.Parse(annualLayout, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeCalendars returns the calendars as they are encoded in a Schedule.
+// Only calendars of type *MemoryCalendar can be encoded.
+func encodeCalendars(calendars []Calendar) ([]*MemoryCalendar, error) {
+	var res []*MemoryCalendar
+	for _, c := range calendars {
+		mc, ok := c.(*MemoryCalendar)
+		if !ok {
+			return nil, fmt.Errorf("timewalk: cannot encode calendar of type %T", c)
+		}
+		res = append(res, mc)
+	}
+	return res, nil
+}
+
+// decodeCalendars checks the calendars decoded from a Schedule.
+func decodeCalendars(calendars []*MemoryCalendar) ([]Calendar, error) {
+	var res []Calendar
+	for _, c := range calendars {
+		if err := c.validate(); err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+// CalendarFromICS reads the all-day events of an iCalendar stream. Events
+// with a yearly RRULE are added as annual holidays, every other event adds
+// each date from DTSTART up to, but not including, DTEND.
+func CalendarFromICS(name string, r io.Reader) (*MemoryCalendar, error) {
+	c := NewCalendar(name)
+	lines, err := unfoldICS(r)
+	if err != nil {
+		return nil, err
+	}
+	var start, end *time.Time
+	yearly, inEvent := false, false
+	for _, line := range lines {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, _, _ := strings.Cut(key, ";")
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end, yearly = nil, nil, false
+			}
+		case "DTSTART":
+			if inEvent {
+				if start, err = parseICSDate(value); err != nil {
+					return nil, err
+				}
+			}
+		case "DTEND":
+			if inEvent {
+				if end, err = parseICSDate(value); err != nil {
+					return nil, err
+				}
+			}
+		case "RRULE":
+			if inEvent {
+				yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start == nil {
+				return nil, fmt.Errorf("timewalk: event without DTSTART")
+			}
+			if end == nil || !end.After(*start) {
+				end = ptr(start.AddDate(0, 0, 1))
+			}
+			for d := *start; d.Before(*end); d = d.AddDate(0, 0, 1) {
+				if yearly {
+					c.AddAnnual(d.Month(), d.Day())
+				} else {
+					c.AddHoliday(d)
+				}
+			}
+		}
+	}
+	return c, nil
+}
+
+func unfoldICS(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+func parseICSDate(value string) (*time.Time, error) {
+	if len(value) < 8 {
+		return nil, fmt.Errorf("timewalk: invalid date %q", value)
+	}
+	d, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (c *MemoryCalendar) WithWeekend(days ...time.Weekday) *MemoryCalendar {
+	c.Weekend = days
+	return c
+}
+
+func (c *MemoryCalendar) AddHoliday(dates ...time.Time) *MemoryCalendar {
+	for _, d := range dates {
+		c.Holidays = append(c.Holidays, d.Format(dateLayout))
+	}
+	return c
+}
+
+func (c *MemoryCalendar) AddAnnual(month time.Month, day int) *MemoryCalendar {
+	c.Annual = append(c.Annual, fmt.Sprintf("%02d-%02d", month, day))
+	return c
+}
+
+func (c *MemoryCalendar) AddFeast(feasts ...Feast) *MemoryCalendar {
+	c.Feasts = append(c.Feasts, feasts...)
+	return c
+}
+
+func (c *MemoryCalendar) IsHoliday(date time.Time) bool {
+	for _, v := range c.Weekend {
+		if date.Weekday() == v {
+			return true
+		}
+	}
+	day := date.Format(dateLayout)
+	for _, v := range c.Holidays {
+		if v == day {
+			return true
+		}
+	}
+	annual := date.Format(annualLayout)
+	for _, v := range c.Annual {
+		if v == annual {
+			return true
+		}
+	}
+	for _, v := range c.Feasts {
+		if v.Date(date.Year(), time.UTC).Format(dateLayout) == day {
+			return true
+		}
+	}
+	return false
+}
+
+// maxShiftDays bounds the search for a business day when shifting an
+// occurrence off a holiday.
+const maxShiftDays = 31
+
+// maxShiftScan bounds the number of days examined before giving up on a
+// schedule whose occurrences all fall on holidays.
+const maxShiftScan = 10000
+
+// ShiftPolicy decides what happens to an occurrence falling on a holiday.
+type ShiftPolicy int
+
+const (
+	// ShiftSkip drops the occurrence.
+	ShiftSkip ShiftPolicy = iota
+	// ShiftNext moves the occurrence to the next business day.
+	ShiftNext
+	// ShiftPrevious moves the occurrence to the previous business day.
+	ShiftPrevious
+)
+
+func (p ShiftPolicy) String() string {
+	switch p {
+	case ShiftNext:
+		return "moved to next business day on holidays"
+	case ShiftPrevious:
+		return "moved to previous business day on holidays"
+	default:
+		return "skipping holidays"
+	}
+}
+
+func (s *Schedule) isHoliday(date time.Time) bool {
+	for _, c := range s.Calendars {
+		if c.IsHoliday(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftDate returns the date an occurrence on date runs on, or nil when it
+// is dropped.
+func (s *Schedule) shiftDate(date time.Time) *time.Time {
+	if !s.isHoliday(date) {
+		return &date
+	}
+	step := 0
+	switch s.Shift {
+	case ShiftNext:
+		step = 1
+	case ShiftPrevious:
+		step = -1
+	default:
+		return nil
+	}
+	for i := 0; i < maxShiftDays; i++ {
+		date = date.AddDate(0, 0, step)
+		if !s.isHoliday(date) {
+			return &date
+		}
+	}
+	return nil
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func atClock(date time.Time, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, date.Location())
+}
+
+// nextShifted walks the raw occurrences day by day, starting far enough
+// back to catch those shifted forward onto t, and keeps the earliest
+// shifted occurrence at or after t.
+func (s *Schedule) nextShifted(t time.Time) *time.Time {
+	today := dateOf(t)
+	var best *time.Time
+	day := today.AddDate(0, 0, -maxShiftDays)
+	for i := 0; i < maxShiftScan && (best == nil || !day.AddDate(0, 0, -maxShiftDays).After(*best)); i++ {
+		occ := s.next(day)
+		if occ == nil {
+			break
+		}
+		if !dateOf(*occ).Equal(day) {
+			day = dateOf(*occ)
+			continue
+		}
+		target := s.shiftDate(day)
+		if target == nil || target.Before(today) {
+			day = day.AddDate(0, 0, 1)
+			continue
+		}
+		if target.Equal(today) {
+			if occ = s.next(atClock(day, t)); occ == nil {
+				break
+			}
+			if !dateOf(*occ).Equal(day) {
+				day = dateOf(*occ)
+				continue
+			}
+		}
+		res := atClock(*target, *occ)
+		if best == nil || res.Before(*best) {
+			best = &res
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return best
+}
+
+// previousShifted mirrors nextShifted walking backward from t.
+func (s *Schedule) previousShifted(t time.Time) *time.Time {
+	today := dateOf(t)
+	var best *time.Time
+	day := today.AddDate(0, 0, maxShiftDays)
+	for i := 0; i < maxShiftScan && (best == nil || !day.AddDate(0, 0, maxShiftDays).Before(*best)); i++ {
+		occ := s.previous(day.AddDate(0, 0, 1).Add(-time.Second))
+		if occ == nil {
+			break
+		}
+		if !dateOf(*occ).Equal(day) {
+			day = dateOf(*occ)
+			continue
+		}
+		target := s.shiftDate(day)
+		if target == nil || target.After(today) {
+			day = day.AddDate(0, 0, -1)
+			continue
+		}
+		if target.Equal(today) {
+			if occ = s.previous(atClock(day, t)); occ == nil {
+				break
+			}
+			if !dateOf(*occ).Equal(day) {
+				day = dateOf(*occ)
+				continue
+			}
+		}
+		res := atClock(*target, *occ)
+		if best == nil || res.After(*best) {
+			best = &res
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return best
+}
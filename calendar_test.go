@@ -0,0 +1,140 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryCalendar_IsHoliday(t *testing.T) {
+	c := NewCalendar("vn").
+		WithWeekend(time.Saturday, time.Sunday).
+		AddHoliday(time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)).
+		AddAnnual(time.September, 2)
+	// weekend
+	assert.True(t, c.IsHoliday(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)))
+	// holiday
+	assert.True(t, c.IsHoliday(time.Date(2024, 2, 12, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, c.IsHoliday(time.Date(2025, 2, 12, 0, 0, 0, 0, time.UTC)))
+	// annual
+	assert.True(t, c.IsHoliday(time.Date(2024, 9, 2, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, c.IsHoliday(time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC)))
+	// business day
+	assert.False(t, c.IsHoliday(time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCalendarFromJSON(t *testing.T) {
+	c, err := CalendarFromJSON(`{"name":"vn","weekend":[0,6],"holidays":["2024-02-12"],"annual":["09-02"]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "vn", c.Name)
+	assert.True(t, c.IsHoliday(time.Date(2024, 2, 11, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, c.IsHoliday(time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, c.IsHoliday(time.Date(2030, 9, 2, 0, 0, 0, 0, time.UTC)))
+
+	_, err = CalendarFromJSON(`{"holidays":["2024-13-01"]}`)
+	assert.Error(t, err)
+	_, err = CalendarFromJSON(`{`)
+	assert.Error(t, err)
+}
+
+func TestCalendarFromICS(t *testing.T) {
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Tet",
+		"DTSTART;VALUE=DATE:20240209",
+		"DTEND;VALUE=DATE:20240215",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:National",
+		" Day",
+		"DTSTART;VALUE=DATE:20230902",
+		"RRULE:FREQ=YEARLY",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+	c, err := CalendarFromICS("vn", strings.NewReader(ics))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2024-02-09", "2024-02-10", "2024-02-11", "2024-02-12", "2024-02-13", "2024-02-14"}, c.Holidays)
+	assert.Equal(t, []string{"09-02"}, c.Annual)
+
+	_, err = CalendarFromICS("vn", strings.NewReader("BEGIN:VEVENT\nEND:VEVENT"))
+	assert.Error(t, err)
+}
+
+func TestSchedule_Calendar(t *testing.T) {
+	// 2024-02-12 is a Monday
+	c := NewCalendar("vn").WithWeekend(time.Saturday, time.Sunday).
+		AddHoliday(time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC))
+	s := Scheduler().WithLoc(time.UTC).WithCalendar(c).Hour(At(9)).Minute(At(0)).Second(At(0))
+	fri := time.Date(2024, 2, 9, 9, 0, 0, 0, time.UTC)
+	tue := time.Date(2024, 2, 13, 9, 0, 0, 0, time.UTC)
+
+	// skip
+	assert.Equal(t, &tue, s.NextAfter(fri))
+	assert.Equal(t, &fri, s.PreviousBefore(tue))
+	assert.Equal(t, &tue, s.Next(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "at 9th hour, at 0th minute, at 0th second, skipping holidays", s.String())
+
+	// next business day
+	s.WithShift(ShiftNext).Day(At(10))
+	assert.Equal(t, &tue, s.Next(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, &tue, s.Next(time.Date(2024, 2, 13, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, &tue, s.Previous(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)), s.NextAfter(tue))
+
+	// previous business day
+	s.WithShift(ShiftPrevious)
+	assert.Equal(t, &fri, s.Next(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, &fri, s.Previous(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)), s.NextAfter(fri))
+	assert.Equal(t, "at 10th day, at 9th hour, at 0th minute, at 0th second, moved to previous business day on holidays", s.String())
+
+	// in progress on the shifted date
+	s.WithDuration(time.Hour)
+	assert.True(t, s.InProgress(fri.Add(time.Minute)))
+	assert.False(t, s.InProgress(time.Date(2024, 2, 10, 9, 1, 0, 0, time.UTC)))
+}
+
+func TestSchedule_CalendarJSON(t *testing.T) {
+	c := NewCalendar("vn").WithWeekend(time.Saturday, time.Sunday).
+		AddHoliday(time.Date(2024, 2, 12, 0, 0, 0, 0, time.UTC))
+	s := Scheduler().WithLoc(time.UTC).WithCalendar(c).WithShift(ShiftNext).Day(At(10)).Hour(At(9)).Minute(At(0)).Second(At(0))
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	fromJSON, err := ScheduleFromJSON(string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []Calendar{c}, fromJSON.Calendars)
+	assert.Equal(t, s.String(), fromJSON.String())
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, ptr(time.Date(2024, 2, 13, 9, 0, 0, 0, time.UTC)), fromJSON.Next(from))
+	assert.Equal(t, s.Next(from), fromJSON.Next(from))
+
+	// in a list
+	data, err = json.Marshal(Schedulers{s})
+	assert.NoError(t, err)
+	var list Schedulers
+	assert.NoError(t, json.Unmarshal(data, &list))
+	assert.Equal(t, s.Next(from), list[0].Next(from))
+
+	// calendars not in memory cannot be encoded
+	_, err = json.Marshal(Scheduler().WithCalendar(weekdays{}))
+	assert.Error(t, err)
+	_, err = ScheduleFromJSON(`{"calendars":[{"holidays":["2024-13-01"]}]}`)
+	assert.Error(t, err)
+}
+
+type weekdays struct{}
+
+func (weekdays) IsHoliday(date time.Time) bool {
+	return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+}
+
+func TestSchedule_CalendarAllHolidays(t *testing.T) {
+	c := NewCalendar("closed").WithWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	s := Scheduler().WithLoc(time.UTC).WithCalendar(c).Year(From(2024).To(2025))
+	assert.Nil(t, s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Nil(t, s.Previous(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,77 @@
+package timewalk
+
+import "time"
+
+// onTime is how late a run may start and still not count as missed.
+const onTime = time.Second
+
+// CatchUpPolicy is what a Runner does with the occurrences it missed, as
+// it was not running or woke up late.
+type CatchUpPolicy int
+
+const (
+	// CatchUpLatest runs the latest missed occurrence only.
+	CatchUpLatest CatchUpPolicy = iota
+	// CatchUpAll runs every missed occurrence, oldest first.
+	CatchUpAll
+	// CatchUpSkip runs none of them.
+	CatchUpSkip
+)
+
+var catchUpPolicyNames = map[CatchUpPolicy]string{
+	CatchUpLatest: "latest",
+	CatchUpAll:    "all",
+	CatchUpSkip:   "skip",
+}
+
+func (p CatchUpPolicy) String() string {
+	return catchUpPolicyNames[p]
+}
+
+// WithCatchUp sets the catch-up policy of the job. Missed occurrences more
+// than deadline ago are dropped whatever the policy, a zero deadline
+// keeping them all.
+func WithCatchUp(policy CatchUpPolicy, deadline time.Duration) JobOption {
+	return func(e *entry) {
+		e.catchUp = policy
+		e.deadline = deadline
+	}
+}
+
+// WithLastRun records the occurrence the job last ran for, e.g. before a
+// restart, so that the occurrences since are caught up on.
+func WithLastRun(t time.Time) JobOption {
+	return func(e *entry) {
+		e.prev = &t
+	}
+}
+
+// reset points e at the first occurrence after its last run, or at or
+// after now when it never ran.
+func (e *entry) reset(now time.Time) {
+	if e.prev != nil {
+		e.at = e.next(e.prev.Truncate(time.Second).Add(time.Second))
+		return
+	}
+	e.at = e.next(ceil(now))
+}
+
+// due walks e past now and returns the occurrences to run, following its
+// catch-up policy.
+func (e *entry) due(now time.Time) []time.Time {
+	res := make([]time.Time, 0)
+	for ; e.at != nil && !e.at.After(now); e.at = e.next(e.at.Truncate(time.Second).Add(time.Second)) {
+		at := *e.at
+		late := now.Sub(at) >= onTime
+		switch {
+		case late && (e.catchUp == CatchUpSkip || e.deadline > 0 && now.Sub(at) > e.deadline):
+			e.missed++
+			continue
+		case e.catchUp == CatchUpLatest && len(res) > 0:
+			e.missed += len(res)
+			res = res[:0]
+		}
+		res = append(res, at)
+	}
+	return res
+}
@@ -0,0 +1,53 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func hourly(policy CatchUpPolicy, deadline time.Duration) *entry {
+	s := Scheduler().WithLoc(time.UTC).Minute(At(0)).Second(At(0))
+	e := &entry{next: s.Next}
+	WithCatchUp(policy, deadline)(e)
+	// down from 01:00 to 05:00
+	WithLastRun(time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC))(e)
+	e.reset(time.Date(2024, 1, 1, 5, 0, 30, 0, time.UTC))
+	return e
+}
+
+func TestEntry_Due(t *testing.T) {
+	now := time.Date(2024, 1, 1, 5, 0, 30, 0, time.UTC)
+	at := func(hour int) time.Time {
+		return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC)
+	}
+
+	e := hourly(CatchUpAll, 0)
+	assert.Equal(t, []time.Time{at(2), at(3), at(4), at(5)}, e.due(now))
+	assert.Equal(t, 0, e.missed)
+	assert.Equal(t, at(6), *e.at)
+
+	e = hourly(CatchUpLatest, 0)
+	assert.Equal(t, []time.Time{at(5)}, e.due(now))
+	assert.Equal(t, 3, e.missed)
+
+	e = hourly(CatchUpSkip, 0)
+	assert.Empty(t, e.due(now))
+	assert.Equal(t, 4, e.missed)
+	assert.Equal(t, at(6), *e.at)
+
+	// past the starting deadline
+	e = hourly(CatchUpAll, 2*time.Hour)
+	assert.Equal(t, []time.Time{at(4), at(5)}, e.due(now))
+	assert.Equal(t, 2, e.missed)
+	e = hourly(CatchUpLatest, time.Minute)
+	assert.Equal(t, []time.Time{at(5)}, e.due(now))
+	e = hourly(CatchUpLatest, 10*time.Second)
+	assert.Empty(t, e.due(now))
+
+	// on time occurrences run whatever the policy
+	e = hourly(CatchUpSkip, 0)
+	assert.Equal(t, []time.Time{at(5)}, e.due(at(5).Add(500*time.Millisecond)))
+	assert.Equal(t, 3, e.missed)
+	assert.Equal(t, "skip", CatchUpSkip.String())
+}
@@ -0,0 +1,153 @@
+package timewalk
+
+import "time"
+
+// maxDSTScan bounds the number of wall clock occurrences dropped by the DST
+// policies before Next and Previous give up.
+const maxDSTScan = 10000
+
+// DSTGap decides what happens to an occurrence whose wall clock time does
+// not exist because clocks were set forward.
+type DSTGap int
+
+const (
+	// DSTGapShift runs at the instant the wall clock time would have had
+	// without the transition, e.g. 02:30 becomes 03:30 after a one hour gap.
+	DSTGapShift DSTGap = iota
+	// DSTGapSkip drops the occurrence.
+	DSTGapSkip
+)
+
+// DSTOverlap decides what happens to an occurrence whose wall clock time
+// happens twice because clocks were set back.
+type DSTOverlap int
+
+const (
+	// DSTOverlapFirst runs once, at the earlier instant.
+	DSTOverlapFirst DSTOverlap = iota
+	// DSTOverlapLast runs once, at the later instant.
+	DSTOverlapLast
+	// DSTOverlapBoth runs at both instants.
+	DSTOverlapBoth
+)
+
+// instants returns the instants t's wall clock fields denote in its
+// location, in chronological order: none in a DST gap, two in an overlap.
+func (t *Time) instants() []time.Time {
+	wall := time.Date(t.Year, t.Month, t.Day, t.Hour, t.Minute, t.Second, 0, time.UTC)
+	res := make([]time.Time, 0, 2)
+	for _, at := range []time.Time{wall.Add(-24 * time.Hour), wall.Add(24 * time.Hour)} {
+		_, offset := at.In(t.Loc).Zone()
+		v := wall.Add(-time.Duration(offset) * time.Second).In(t.Loc)
+		if !t.sameWall(T(v)) {
+			continue
+		}
+		if len(res) == 0 || v.After(res[0]) {
+			res = append(res, v)
+		} else if v.Before(res[0]) {
+			res = append([]time.Time{v}, res...)
+		}
+	}
+	return res
+}
+
+func (t *Time) sameWall(o Time) bool {
+	return t.Year == o.Year && t.Month == o.Month && t.Day == o.Day &&
+		t.Hour == o.Hour && t.Minute == o.Minute && t.Second == o.Second
+}
+
+// shifted returns the instant of a wall clock time falling in a DST gap,
+// read with the offset in effect before the gap.
+func (t *Time) shifted() time.Time {
+	wall := time.Date(t.Year, t.Month, t.Day, t.Hour, t.Minute, t.Second, 0, time.UTC)
+	_, offset := wall.Add(-24 * time.Hour).In(t.Loc).Zone()
+	return wall.Add(-time.Duration(offset) * time.Second).In(t.Loc)
+}
+
+// add moves t by d on the wall clock, ignoring DST transitions.
+func (t *Time) add(d time.Duration) Time {
+	wall := time.Date(t.Year, t.Month, t.Day, t.Hour, t.Minute, t.Second, 0, time.UTC).Add(d)
+	res := T(wall)
+	res.Loc = t.Loc
+	return res
+}
+
+// resolve applies the DST policies to a wall clock occurrence.
+func (s *Schedule) resolve(occ *Time) []time.Time {
+	instants := occ.instants()
+	switch {
+	case len(instants) == 0 && s.DSTGap == DSTGapSkip:
+		return nil
+	case len(instants) == 0:
+		return []time.Time{occ.shifted()}
+	case len(instants) == 2 && s.DSTOverlap == DSTOverlapFirst:
+		return instants[:1]
+	case len(instants) == 2 && s.DSTOverlap == DSTOverlapLast:
+		return instants[1:]
+	}
+	return instants
+}
+
+func (s *Schedule) next(t time.Time) *time.Time {
+	t = t.In(s.Loc)
+	from := t.Truncate(time.Second)
+	now := T(t)
+	res := s.scanNext(now, from)
+	// during the first pass of a repeated hour, earlier wall clock times
+	// still happen again after t
+	if instants := now.instants(); len(instants) == 2 && from.Before(instants[1]) {
+		v := s.scanNext(now.add(-instants[1].Sub(instants[0])), from)
+		if v != nil && (res == nil || v.Before(*res)) {
+			res = v
+		}
+	}
+	return res
+}
+
+func (s *Schedule) scanNext(now Time, from time.Time) *time.Time {
+	for i := 0; i < maxDSTScan; i++ {
+		occ := s.nextWall(now)
+		if occ == nil {
+			return nil
+		}
+		for _, v := range s.resolve(occ) {
+			if !v.Before(from) {
+				return &v
+			}
+		}
+		now = occ.add(time.Second)
+	}
+	return nil
+}
+
+func (s *Schedule) previous(t time.Time) *time.Time {
+	t = t.In(s.Loc)
+	now := T(t)
+	res := s.scanPrevious(now, t)
+	// during the second pass of a repeated hour, later wall clock times
+	// already happened before t
+	if instants := now.instants(); len(instants) == 2 && instants[0].Before(t.Truncate(time.Second)) {
+		v := s.scanPrevious(now.add(instants[1].Sub(instants[0])), t)
+		if v != nil && (res == nil || v.After(*res)) {
+			res = v
+		}
+	}
+	return res
+}
+
+func (s *Schedule) scanPrevious(now Time, to time.Time) *time.Time {
+	for i := 0; i < maxDSTScan; i++ {
+		occ := s.previousWall(now)
+		if occ == nil {
+			return nil
+		}
+		instants := s.resolve(occ)
+		for j := len(instants) - 1; j >= 0; j-- {
+			if !instants[j].After(to) {
+				return &instants[j]
+			}
+		}
+		now = occ.add(-time.Second)
+	}
+	return nil
+}
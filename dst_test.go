@@ -0,0 +1,71 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedule_DSTGap(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	// 2024-03-10 02:00 EST jumps to 03:00 EDT
+	s := Scheduler().WithLoc(ny).Hour(At(2)).Minute(At(30)).Second(At(0))
+	from := time.Date(2024, 3, 10, 0, 0, 0, 0, ny)
+	shifted := time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC)
+	assert.True(t, shifted.Equal(*s.Next(from)))
+	assert.True(t, shifted.Equal(*s.Previous(from.Add(6 * time.Hour))))
+	s.WithDSTGap(DSTGapSkip)
+	assert.Equal(t, ptr(time.Date(2024, 3, 11, 2, 30, 0, 0, ny)), s.Next(from))
+	assert.Equal(t, ptr(time.Date(2024, 3, 9, 2, 30, 0, 0, ny)), s.Previous(from.Add(6*time.Hour)))
+
+	// hourly jobs keep running around the gap
+	s = Scheduler().WithLoc(ny).Minute(At(30)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 3, 10, 3, 30, 0, 0, ny)), s.NextAfter(time.Date(2024, 3, 10, 1, 30, 0, 0, ny)))
+	assert.Equal(t, ptr(time.Date(2024, 3, 10, 4, 30, 0, 0, ny)), s.NextAfter(time.Date(2024, 3, 10, 3, 30, 0, 0, ny)))
+
+	london, err := time.LoadLocation("Europe/London")
+	assert.NoError(t, err)
+	// 2024-03-31 01:00 GMT jumps to 02:00 BST
+	s = Scheduler().WithLoc(london).Hour(At(1)).Minute(At(30)).Second(At(0))
+	assert.True(t, time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC).Equal(*s.Next(time.Date(2024, 3, 31, 0, 0, 0, 0, london))))
+	s.WithDSTGap(DSTGapSkip)
+	assert.Equal(t, ptr(time.Date(2024, 4, 1, 1, 30, 0, 0, london)), s.Next(time.Date(2024, 3, 31, 0, 0, 0, 0, london)))
+}
+
+func TestSchedule_DSTOverlap(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	// 2024-11-03 02:00 EDT falls back to 01:00 EST
+	s := Scheduler().WithLoc(ny).Hour(At(1)).Minute(At(30)).Second(At(0))
+	from := time.Date(2024, 11, 3, 0, 0, 0, 0, ny)
+	first := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC)
+	last := time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC)
+	after := time.Date(2024, 11, 3, 8, 0, 0, 0, time.UTC)
+	tomorrow := time.Date(2024, 11, 4, 1, 30, 0, 0, ny)
+
+	// first
+	assert.True(t, first.Equal(*s.Next(from)))
+	assert.True(t, tomorrow.Equal(*s.NextAfter(first)))
+	assert.True(t, first.Equal(*s.Previous(after)))
+	// last
+	s.WithDSTOverlap(DSTOverlapLast)
+	assert.True(t, last.Equal(*s.Next(from)))
+	assert.True(t, tomorrow.Equal(*s.NextAfter(last)))
+	assert.True(t, last.Equal(*s.Previous(after)))
+	// both
+	s.WithDSTOverlap(DSTOverlapBoth)
+	assert.True(t, first.Equal(*s.Next(from)))
+	assert.True(t, last.Equal(*s.NextAfter(first)))
+	assert.True(t, tomorrow.Equal(*s.NextAfter(last)))
+	assert.True(t, last.Equal(*s.Previous(after)))
+	assert.True(t, first.Equal(*s.PreviousBefore(last)))
+
+	london, err := time.LoadLocation("Europe/London")
+	assert.NoError(t, err)
+	// 2024-10-27 02:00 BST falls back to 01:00 GMT
+	s = Scheduler().WithLoc(london).Hour(At(1)).Minute(At(30)).Second(At(0))
+	assert.True(t, time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC).Equal(*s.Next(time.Date(2024, 10, 27, 0, 0, 0, 0, london))))
+	s.WithDSTOverlap(DSTOverlapLast)
+	assert.True(t, time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC).Equal(*s.Next(time.Date(2024, 10, 27, 0, 0, 0, 0, london))))
+}
@@ -0,0 +1,94 @@
+package timewalk
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Feast is a day given as an offset in days from Easter Sunday.
+type Feast int
+
+const (
+	GoodFriday    Feast = -2
+	EasterSunday  Feast = 0
+	EasterMonday  Feast = 1
+	Ascension     Feast = 39
+	WhitSunday    Feast = 49
+	WhitMonday    Feast = 50
+	CorpusChristi Feast = 60
+)
+
+var feastNames = map[Feast]string{
+	GoodFriday:    "Good Friday",
+	EasterSunday:  "Easter Sunday",
+	EasterMonday:  "Easter Monday",
+	Ascension:     "Ascension",
+	WhitSunday:    "Whit Sunday",
+	WhitMonday:    "Whit Monday",
+	CorpusChristi: "Corpus Christi",
+}
+
+func EasterOffset(days int) Feast {
+	return Feast(days)
+}
+
+// Easter returns the month and day of Easter Sunday in the Gregorian
+// calendar, using the anonymous Gregorian computus.
+func Easter(year int) (time.Month, int) {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	n := h + l - 7*m + 114
+	return time.Month(n / 31), n%31 + 1
+}
+
+// Date returns the day the feast falls on in year.
+func (f Feast) Date(year int, loc *time.Location) time.Time {
+	month, day := Easter(year)
+	return time.Date(year, month, day+int(f), 0, 0, 0, 0, loc)
+}
+
+func (f Feast) String() string {
+	if name, ok := feastNames[f]; ok {
+		return name
+	}
+	if f < 0 {
+		return fmt.Sprintf("%d days before Easter", -f)
+	}
+	return fmt.Sprintf("%d days after Easter", f)
+}
+
+// feastPool returns the days of month any of FeastField falls on.
+func (s *Schedule) feastPool(year int, month time.Month) []int {
+	pool := make([]int, 0)
+	for i := 1; i <= maxDay(year, month); i++ {
+		for _, f := range s.FeastField {
+			if d := f.Date(year, s.Loc); d.Month() == month && d.Day() == i {
+				pool = append(pool, i)
+				break
+			}
+		}
+	}
+	return pool
+}
+
+func (s *Schedule) feastString() string {
+	b := strings.Builder{}
+	b.WriteString("at ")
+	b.WriteString(s.FeastField[0].String())
+	for _, v := range s.FeastField[1:] {
+		b.WriteString(" and at ")
+		b.WriteString(v.String())
+	}
+	return b.String()
+}
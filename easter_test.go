@@ -0,0 +1,56 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestEaster(t *testing.T) {
+	dates := map[int][2]int{
+		1818: {3, 22},
+		2000: {4, 23},
+		2019: {4, 21},
+		2024: {3, 31},
+		2025: {4, 20},
+		2038: {4, 25},
+	}
+	for year, date := range dates {
+		month, day := Easter(year)
+		assert.Equal(t, time.Month(date[0]), month, year)
+		assert.Equal(t, date[1], day, year)
+	}
+}
+
+func TestFeast_Date(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), GoodFriday.Date(2024, time.UTC))
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), EasterMonday.Date(2024, time.UTC))
+	assert.Equal(t, time.Date(2024, 5, 9, 0, 0, 0, 0, time.UTC), Ascension.Date(2024, time.UTC))
+	assert.Equal(t, time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC), WhitMonday.Date(2024, time.UTC))
+	assert.Equal(t, time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC), EasterOffset(3).Date(2024, time.UTC))
+}
+
+func TestFeast_String(t *testing.T) {
+	assert.Equal(t, "Easter Monday", EasterMonday.String())
+	assert.Equal(t, "3 days after Easter", EasterOffset(3).String())
+	assert.Equal(t, "46 days before Easter", EasterOffset(-46).String())
+}
+
+func TestSchedule_Feast(t *testing.T) {
+	s := Scheduler().WithLoc(time.UTC).Feast(EasterMonday, WhitMonday).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)), s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2025, 4, 21, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 5, 20, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2023, 5, 29, 0, 0, 0, 0, time.UTC)), s.Previous(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "at Easter Monday and at Whit Monday, at 0th hour, at 0th minute, at 0th second", s.String())
+}
+
+func TestMemoryCalendar_Feast(t *testing.T) {
+	c := NewCalendar("de").WithWeekend(time.Saturday, time.Sunday).AddFeast(GoodFriday, EasterMonday)
+	assert.True(t, c.IsHoliday(time.Date(2024, 3, 29, 10, 0, 0, 0, time.UTC)))
+	assert.True(t, c.IsHoliday(time.Date(2025, 4, 21, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, c.IsHoliday(time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)))
+
+	s := Scheduler().WithLoc(time.UTC).WithCalendar(c).Hour(At(9)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 4, 2, 9, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 3, 28, 9, 0, 0, 0, time.UTC)))
+}
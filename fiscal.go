@@ -0,0 +1,218 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// FiscalCalendar is a 52/53-week fiscal calendar. Each fiscal year ends on
+// the last EndWeekday of EndMonth, or the EndWeekday nearest to the end of
+// EndMonth when Nearest is set, and is named after the calendar year it
+// ends in. Its weeks are grouped into periods following Pattern, repeated
+// to cover 52 weeks; the 53rd week of long years joins the last period.
+type FiscalCalendar struct {
+	EndMonth   time.Month   `json:"end_month"`
+	EndWeekday time.Weekday `json:"end_weekday"`
+	Nearest    bool         `json:"nearest"`
+	Pattern    []int        `json:"pattern"`
+}
+
+// FiscalDate is a date in a fiscal calendar.
+type FiscalDate struct {
+	Year   int
+	Period int
+	Week   int // week of the fiscal year
+	Day    int // day of the period
+	// DaysInPeriod is the length of the period in days.
+	DaysInPeriod int
+}
+
+// NewFiscalCalendar returns a fiscal calendar ending on the last
+// endWeekday of endMonth, e.g. a 4-4-5 calendar is
+// NewFiscalCalendar(time.January, time.Saturday, 4, 4, 5). Periods of no
+// week are left out, and a pattern with none left is a single period.
+func NewFiscalCalendar(endMonth time.Month, endWeekday time.Weekday, pattern ...int) *FiscalCalendar {
+	return &FiscalCalendar{
+		EndMonth:   endMonth,
+		EndWeekday: endWeekday,
+		Pattern:    positive(pattern),
+	}
+}
+
+func (c *FiscalCalendar) UnmarshalJSON(data []byte) error {
+	type fields FiscalCalendar
+	if err := json.Unmarshal(data, (*fields)(c)); err != nil {
+		return err
+	}
+	c.Pattern = positive(c.Pattern)
+	return nil
+}
+
+// positive returns the positive values of pattern.
+func positive(pattern []int) []int {
+	res := make([]int, 0, len(pattern))
+	for _, v := range pattern {
+		if v > 0 {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func (c *FiscalCalendar) WithNearest(nearest bool) *FiscalCalendar {
+	c.Nearest = nearest
+	return c
+}
+
+// YearEnd returns the last day of fiscal year.
+func (c *FiscalCalendar) YearEnd(year int, loc *time.Location) time.Time {
+	last := time.Date(year, c.EndMonth, maxDay(year, c.EndMonth), 0, 0, 0, 0, loc)
+	back := (int(last.Weekday()) - int(c.EndWeekday) + 7) % 7
+	if c.Nearest && back > 3 {
+		return last.AddDate(0, 0, 7-back)
+	}
+	return last.AddDate(0, 0, -back)
+}
+
+// periods returns the number of weeks in each period of a 52-week year.
+func (c *FiscalCalendar) periods() []int {
+	res := make([]int, 0)
+	pattern := positive(c.Pattern)
+	weeks := 0
+	for len(pattern) > 0 && weeks < 52 {
+		for _, v := range pattern {
+			if weeks >= 52 {
+				break
+			}
+			res = append(res, v)
+			weeks += v
+		}
+	}
+	if len(res) == 0 {
+		res = append(res, 52)
+	}
+	return res
+}
+
+// Date returns the fiscal date of the civil date of t.
+func (c *FiscalCalendar) Date(t time.Time) FiscalDate {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	year := t.Year()
+	if day.After(c.YearEnd(year, time.UTC)) {
+		year++
+	} else if !day.After(c.YearEnd(year-1, time.UTC)) {
+		year--
+	}
+	start := c.YearEnd(year-1, time.UTC).AddDate(0, 0, 1)
+	weeksInYear := (daysBetween(start, c.YearEnd(year, time.UTC)) + 1) / 7
+	res := FiscalDate{Year: year}
+	days := daysBetween(start, day)
+	res.Week = days/7 + 1
+	periods := c.periods()
+	first := 1
+	for i, weeks := range periods {
+		if i == len(periods)-1 {
+			weeks += weeksInYear - 52
+		}
+		if res.Week < first+weeks {
+			res.Period = i + 1
+			res.Day = days - (first-1)*7 + 1
+			res.DaysInPeriod = weeks * 7
+			break
+		}
+		first += weeks
+	}
+	return res
+}
+
+func daysBetween(from, to time.Time) int {
+	return jdFromDate(to.Day(), int(to.Month()), to.Year()) - jdFromDate(from.Day(), int(from.Month()), from.Year())
+}
+
+func (s *Schedule) WithFiscal(c *FiscalCalendar) *Schedule {
+	s.Fiscal = c
+	return s
+}
+
+func (s *Schedule) FiscalYear(units ...*Unit[int]) *Schedule {
+	s.FiscalYearField = units
+	return s
+}
+
+func (s *Schedule) FiscalPeriod(units ...*Unit[int]) *Schedule {
+	s.FiscalPeriodField = units
+	return s
+}
+
+func (s *Schedule) FiscalWeek(units ...*Unit[int]) *Schedule {
+	s.FiscalWeekField = units
+	return s
+}
+
+// FiscalDay matches the nth day of the fiscal period.
+func (s *Schedule) FiscalDay(units ...*Unit[int]) *Schedule {
+	s.FiscalDayField = units
+	s.FiscalDayFromEnd = false
+	return s
+}
+
+// LastFiscalDay matches the nth day counted back from the end of the
+// fiscal period, At(1) being its last day.
+func (s *Schedule) LastFiscalDay(units ...*Unit[int]) *Schedule {
+	s.FiscalDayField = units
+	s.FiscalDayFromEnd = true
+	return s
+}
+
+func (s *Schedule) hasFiscal() bool {
+	return s.Fiscal != nil && (len(s.FiscalYearField) > 0 || len(s.FiscalPeriodField) > 0 ||
+		len(s.FiscalWeekField) > 0 || len(s.FiscalDayField) > 0)
+}
+
+func (s *Schedule) fiscalMatch(date time.Time) bool {
+	f := s.Fiscal.Date(date)
+	day := f.Day
+	if s.FiscalDayFromEnd {
+		day = f.DaysInPeriod - f.Day + 1
+	}
+	return (len(s.FiscalYearField) == 0 || s.FiscalYearField.Match(f.Year)) &&
+		(len(s.FiscalPeriodField) == 0 || s.FiscalPeriodField.Match(f.Period)) &&
+		(len(s.FiscalWeekField) == 0 || s.FiscalWeekField.Match(f.Week)) &&
+		(len(s.FiscalDayField) == 0 || s.FiscalDayField.Match(day))
+}
+
+// fiscalPool returns the days of month matching the fiscal fields.
+func (s *Schedule) fiscalPool(year int, month time.Month) []int {
+	pool := make([]int, 0)
+	for i := 1; i <= maxDay(year, month); i++ {
+		if s.fiscalMatch(time.Date(year, month, i, 0, 0, 0, 0, s.Loc)) {
+			pool = append(pool, i)
+		}
+	}
+	return pool
+}
+
+func (s *Schedule) fiscalString() string {
+	parts := make([]string, 0)
+	if s.FiscalYearField != nil {
+		parts = append(parts, s.FiscalYearField.String("fiscal year"))
+	}
+	if s.FiscalPeriodField != nil {
+		parts = append(parts, s.FiscalPeriodField.String("fiscal period"))
+	}
+	if s.FiscalWeekField != nil {
+		parts = append(parts, s.FiscalWeekField.String("fiscal week"))
+	}
+	if s.FiscalDayField != nil {
+		f := s.FiscalDayField
+		if s.FiscalDayFromEnd && len(f) == 1 && f[0].Type == TValue && *f[0].Value == 1 {
+			parts = append(parts, "at last day of fiscal period")
+		} else if s.FiscalDayFromEnd {
+			parts = append(parts, f.String("day from the end of fiscal period"))
+		} else {
+			parts = append(parts, f.String("day of fiscal period"))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
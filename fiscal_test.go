@@ -0,0 +1,70 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFiscalCalendar_YearEnd(t *testing.T) {
+	c := NewFiscalCalendar(time.January, time.Saturday, 4, 4, 5)
+	assert.Equal(t, time.Date(2024, 1, 27, 0, 0, 0, 0, time.UTC), c.YearEnd(2024, time.UTC))
+	c.WithNearest(true)
+	assert.Equal(t, time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC), c.YearEnd(2024, time.UTC))
+	assert.Equal(t, time.Date(2023, 1, 28, 0, 0, 0, 0, time.UTC), c.YearEnd(2023, time.UTC))
+}
+
+func TestFiscalCalendar_Date(t *testing.T) {
+	// fiscal 2024 runs from 2023-01-29 through 2024-02-03, 53 weeks
+	c := NewFiscalCalendar(time.January, time.Saturday, 4, 4, 5).WithNearest(true)
+	assert.Equal(t, FiscalDate{Year: 2024, Period: 1, Week: 1, Day: 1, DaysInPeriod: 28}, c.Date(time.Date(2023, 1, 29, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, FiscalDate{Year: 2023, Period: 12, Week: 52, Day: 35, DaysInPeriod: 35}, c.Date(time.Date(2023, 1, 28, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, FiscalDate{Year: 2024, Period: 3, Week: 9, Day: 1, DaysInPeriod: 35}, c.Date(time.Date(2023, 3, 26, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, FiscalDate{Year: 2024, Period: 12, Week: 53, Day: 42, DaysInPeriod: 42}, c.Date(time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, FiscalDate{Year: 2025, Period: 1, Week: 1, Day: 1, DaysInPeriod: 28}, c.Date(time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)))
+
+	// without a pattern the year is a single period
+	c = NewFiscalCalendar(time.December, time.Sunday)
+	assert.Equal(t, 1, c.Date(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)).Period)
+}
+
+func TestFiscalCalendar_BadPattern(t *testing.T) {
+	at := time.Date(2023, 3, 26, 0, 0, 0, 0, time.UTC)
+	want := NewFiscalCalendar(time.January, time.Saturday, 4, 4, 5).WithNearest(true).Date(at)
+	// periods of no week are left out
+	c := NewFiscalCalendar(time.January, time.Saturday, 0, 4, -1, 4, 5).WithNearest(true)
+	assert.Equal(t, []int{4, 4, 5}, c.Pattern)
+	assert.Equal(t, want, c.Date(at))
+	c = &FiscalCalendar{EndMonth: time.January, EndWeekday: time.Saturday, Nearest: true, Pattern: []int{0, 4, 4, 5}}
+	assert.Equal(t, want, c.Date(at))
+
+	// from JSON
+	s, err := ScheduleFromJSON(`{"location":"UTC","fiscal":{"end_month":1,"end_weekday":6,"nearest":true,"pattern":[0,4,4,5]}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 4, 5}, s.Fiscal.Pattern)
+	s, err = ScheduleFromJSON(`{"location":"UTC","fiscal":{"end_month":1,"end_weekday":6,"pattern":[0,-4]},"fiscal_period":[{"type":2,"value":1}]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, s.Fiscal.Date(at).Period)
+	assert.NotNil(t, s.Next(at))
+}
+
+func TestSchedule_Fiscal(t *testing.T) {
+	c := NewFiscalCalendar(time.January, time.Saturday, 4, 4, 5).WithNearest(true)
+	// last day of every fiscal period
+	s := Scheduler().WithLoc(time.UTC).WithFiscal(c).LastFiscalDay(At(1)).Hour(At(18)).Minute(At(0)).Second(At(0))
+	first := time.Date(2023, 2, 25, 18, 0, 0, 0, time.UTC)
+	assert.Equal(t, &first, s.Next(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2023, 3, 25, 18, 0, 0, 0, time.UTC)), s.NextAfter(first))
+	assert.Equal(t, ptr(time.Date(2023, 4, 29, 18, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2023, 3, 25, 18, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 2, 3, 18, 0, 0, 0, time.UTC)), s.Previous(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "at last day of fiscal period, at 18th hour, at 0th minute, at 0th second", s.String())
+
+	// first day of the 53rd week
+	s = Scheduler().WithLoc(time.UTC).WithFiscal(c).FiscalWeek(At(53)).FiscalDay(At(36)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 1, 28, 0, 0, 0, 0, time.UTC)), s.Next(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+
+	s = Scheduler().WithLoc(time.UTC).WithFiscal(c).FiscalYear(At(2025)).FiscalPeriod(At(2)).FiscalDay(At(1)).
+		Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)), s.Next(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "at 2025th fiscal year, at 2nd fiscal period, at 1st day of fiscal period, at 0th hour, at 0th minute, at 0th second", s.String())
+}
@@ -0,0 +1,72 @@
+package timewalk
+
+import (
+	"strings"
+	"time"
+)
+
+// IntervalScheduler returns a schedule occurring every period, forward and
+// backward from anchor. Field restrictions are ignored by interval
+// schedules.
+func IntervalScheduler(anchor time.Time, period time.Duration) *Schedule {
+	return Scheduler().WithInterval(anchor, period)
+}
+
+// WithInterval makes s occur every period from anchor. Occurrences are
+// stepped to the second: the anchor is truncated to it, and periods under a
+// second count as one. The
+// interval wins over the solar event, the calendars and the recurrence,
+// which are ignored while it is set.
+func (s *Schedule) WithInterval(anchor time.Time, period time.Duration) *Schedule {
+	s.Interval = period
+	anchor = anchor.Truncate(time.Second)
+	s.AnchorTime = &anchor
+	s.Anchor = anchor.Unix()
+	return s
+}
+
+func (s *Schedule) anchor() time.Time {
+	if s.AnchorTime == nil {
+		return time.Unix(0, 0).In(s.Loc)
+	}
+	return s.AnchorTime.In(s.Loc)
+}
+
+// period returns the interval, at least a second.
+func (s *Schedule) period() time.Duration {
+	if s.Interval < time.Second {
+		return time.Second
+	}
+	return s.Interval
+}
+
+func (s *Schedule) nextInterval(t time.Time) *time.Time {
+	anchor := s.anchor()
+	period := s.period()
+	n := t.Sub(anchor) / period
+	res := anchor.Add(n * period)
+	if res.Before(t) {
+		res = res.Add(period)
+	}
+	return &res
+}
+
+func (s *Schedule) previousInterval(t time.Time) *time.Time {
+	anchor := s.anchor()
+	period := s.period()
+	n := t.Sub(anchor) / period
+	res := anchor.Add(n * period)
+	if res.After(t) {
+		res = res.Add(-period)
+	}
+	return &res
+}
+
+func (s *Schedule) intervalString() string {
+	b := strings.Builder{}
+	b.WriteString("every ")
+	b.WriteString(s.period().String())
+	b.WriteString(" from ")
+	b.WriteString(s.anchor().Format(time.RFC850))
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Interval(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// every 90 minutes
+	s := IntervalScheduler(anchor, 90*time.Minute).WithLoc(time.UTC)
+	assert.Equal(t, &anchor, s.Next(anchor))
+	assert.Equal(t, ptr(anchor.Add(90*time.Minute)), s.NextAfter(anchor))
+	assert.Equal(t, ptr(anchor.Add(90*time.Minute)), s.Next(anchor.Add(time.Hour)))
+	assert.Equal(t, ptr(anchor.Add(24*time.Hour)), s.Next(anchor.Add(23*time.Hour)))
+	assert.Equal(t, &anchor, s.Previous(anchor.Add(time.Hour)))
+	assert.Equal(t, ptr(anchor.Add(-90*time.Minute)), s.PreviousBefore(anchor))
+	// before the anchor
+	assert.Equal(t, ptr(anchor.Add(-90*time.Minute)), s.Next(anchor.Add(-2*time.Hour)))
+	assert.Equal(t, ptr(anchor.Add(-3*time.Hour)), s.Previous(anchor.Add(-2*time.Hour)))
+
+	// every 36 hours
+	s = IntervalScheduler(anchor, 36*time.Hour).WithLoc(time.UTC).WithDuration(time.Hour)
+	assert.Equal(t, ptr(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)), s.NextAfter(anchor))
+	assert.Equal(t, ptr(time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, s.InProgress(time.Date(2024, 1, 2, 12, 30, 0, 0, time.UTC)))
+	assert.False(t, s.InProgress(time.Date(2024, 1, 2, 13, 30, 0, 0, time.UTC)))
+	assert.Equal(t, "every 36h0m0s from Monday, 01-Jan-24 00:00:00 UTC with 1h0m0s duration", s.String())
+}
+
+func TestSchedule_IntervalSubSecond(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// stepped to the second
+	s := IntervalScheduler(anchor, 250*time.Millisecond).WithLoc(time.UTC)
+	count := 0
+	for occ := s.Next(anchor); occ != nil && occ.Before(anchor.Add(8*time.Second)); occ = s.NextAfter(*occ) {
+		assert.Equal(t, anchor.Add(time.Duration(count)*time.Second), *occ)
+		count++
+	}
+	assert.Equal(t, 8, count)
+	assert.Equal(t, ptr(anchor.Add(-time.Second)), s.PreviousBefore(anchor))
+	assert.Equal(t, "every 1s from Monday, 01-Jan-24 00:00:00 UTC", s.String())
+}
+
+func TestSchedule_IntervalPrecedence(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// the interval wins over the recurrence and the calendars
+	s := Scheduler().WithLoc(time.UTC).Recur(anchor, 2, RecurDay).WithInterval(anchor, time.Hour).
+		WithCalendar(NewCalendar("holidays").AddHoliday(anchor))
+	assert.Equal(t, ptr(anchor.Add(time.Hour)), s.NextAfter(anchor))
+	assert.Equal(t, &anchor, s.Next(anchor))
+}
+
+func TestSchedule_IntervalJSON(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := IntervalScheduler(anchor, 90*time.Minute).WithLoc(time.UTC).WithDuration(time.Minute)
+	s.Enable = true
+	data, err := json.Marshal(Schedulers{s})
+	assert.NoError(t, err)
+	var list Schedulers
+	assert.NoError(t, json.Unmarshal(data, &list))
+	assert.Len(t, list, 1)
+	assert.Equal(t, s.String(), list[0].String())
+	assert.Equal(t, s.Next(anchor.Add(time.Hour)), list[0].Next(anchor.Add(time.Hour)))
+
+	// the anchor is kept to the second
+	s = IntervalScheduler(anchor.Add(500*time.Millisecond), time.Hour).WithLoc(time.UTC)
+	data, err = json.Marshal(s)
+	assert.NoError(t, err)
+	fromJSON, err := ScheduleFromJSON(string(data))
+	assert.NoError(t, err)
+	at := anchor.Add(5*time.Hour + 30*time.Minute)
+	assert.Equal(t, ptr(anchor.Add(6*time.Hour)), s.Next(at))
+	assert.Equal(t, s.Next(at), fromJSON.Next(at))
+	assert.True(t, list.InProgress(anchor.Add(90*time.Minute+30*time.Second)))
+	assert.False(t, list.InProgress(anchor.Add(time.Hour)))
+}
@@ -0,0 +1,59 @@
+package timewalk
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// WithJitter delays every occurrence by up to max, in whole seconds. The
+// delay is random but stable: it is derived from the occurrence and
+// JitterSeed, so Next and Previous agree on it. A zero JitterSeed is
+// replaced by a random one.
+func (s *Schedule) WithJitter(max time.Duration) *Schedule {
+	s.Jitter = max
+	if s.JitterSeed == 0 {
+		s.JitterSeed = rand.Int63()
+	}
+	return s
+}
+
+func (s *Schedule) WithJitterSeed(seed int64) *Schedule {
+	s.JitterSeed = seed
+	return s
+}
+
+// jitter returns the delay of the occurrence at t.
+func (s *Schedule) jitter(t time.Time) time.Duration {
+	n := uint64(s.Jitter / time.Second)
+	if n == 0 {
+		return 0
+	}
+	return time.Duration(hash(fmt.Sprint(s.JitterSeed, ":", t.Unix()))%n) * time.Second
+}
+
+// nextJittered looks back far enough for occurrences delayed past t, and
+// keeps the earliest delayed occurrence at or after t.
+func (s *Schedule) nextJittered(t time.Time) *time.Time {
+	var best *time.Time
+	for occ := s.nextRaw(t.Add(-s.Jitter)); occ != nil && (best == nil || occ.Before(*best)); occ = s.nextRaw(occ.Add(time.Second)) {
+		v := occ.Add(s.jitter(*occ))
+		if !v.Before(t.Truncate(time.Second)) && (best == nil || v.Before(*best)) {
+			best = &v
+		}
+	}
+	return best
+}
+
+// previousJittered keeps the latest delayed occurrence at or before t,
+// walking back until earlier occurrences cannot be delayed past it.
+func (s *Schedule) previousJittered(t time.Time) *time.Time {
+	var best *time.Time
+	for occ := s.previousRaw(t); occ != nil && (best == nil || !occ.Add(s.Jitter).Before(*best)); occ = s.previousRaw(occ.Add(-time.Second)) {
+		v := occ.Add(s.jitter(*occ))
+		if !v.After(t) && (best == nil || v.After(*best)) {
+			best = &v
+		}
+	}
+	return best
+}
@@ -0,0 +1,52 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Jitter(t *testing.T) {
+	s := Scheduler().WithLoc(time.UTC).Minute(At(0)).Second(At(0)).WithJitter(10 * time.Minute).WithJitterSeed(42)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := *s.Next(from)
+	assert.Equal(t, from, prev.Truncate(time.Hour))
+	for i := 0; i < 48; i++ {
+		next := s.NextAfter(prev)
+		assert.NotNil(t, next)
+		hour := next.Truncate(time.Hour)
+		assert.Equal(t, prev.Truncate(time.Hour).Add(time.Hour), hour)
+		assert.True(t, next.Sub(hour) < 10*time.Minute)
+		// stable and consistent between Next and Previous
+		assert.Equal(t, next, s.Next(hour))
+		assert.Equal(t, next, s.Previous(next.Add(time.Second)))
+		assert.Equal(t, next, s.Previous(hour.Add(59*time.Minute)))
+		prev = *next
+	}
+	assert.Equal(t, "at 0th minute, at 0th second with up to 10m0s jitter", s.String())
+
+	// the seed picks the delays
+	other := Scheduler().WithLoc(time.UTC).Minute(At(0)).Second(At(0)).WithJitter(10 * time.Minute).WithJitterSeed(43)
+	differ := false
+	for i := 0; i < 24; i++ {
+		at := from.Add(time.Duration(i) * time.Hour)
+		if !s.Next(at).Equal(*other.Next(at)) {
+			differ = true
+		}
+	}
+	assert.True(t, differ)
+
+	assert.NotZero(t, Scheduler().WithJitter(time.Minute).JitterSeed)
+}
+
+func TestSchedule_JitterOverlap(t *testing.T) {
+	// jitter longer than the step still yields ordered occurrences
+	s := Scheduler().WithLoc(time.UTC).Second(At(0)).WithJitter(3 * time.Minute).WithJitterSeed(7)
+	occ := []time.Time{*s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))}
+	for i := 0; i < 20; i++ {
+		next := s.NextAfter(occ[i])
+		assert.True(t, next.After(occ[i]))
+		assert.Equal(t, occ[i], *s.PreviousBefore(*next))
+		occ = append(occ, *next)
+	}
+}
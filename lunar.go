@@ -0,0 +1,190 @@
+package timewalk
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Time zones, in hours east of UTC, the lunisolar calendar is observed in.
+const (
+	LunarVietnam = 7
+	LunarChina   = 8
+)
+
+// LunarDate is a date in the Vietnamese/Chinese lunisolar calendar.
+type LunarDate struct {
+	Year  int
+	Month int
+	Day   int
+	Leap  bool
+}
+
+// Lunar converts the civil date of t to the lunisolar calendar observed at
+// zone hours east of UTC, following Ho Ngoc Duc's algorithm.
+func Lunar(t time.Time, zone int) LunarDate {
+	tz := float64(zone)
+	dayNumber := jdFromDate(t.Day(), int(t.Month()), t.Year())
+	k := int(math.Floor((float64(dayNumber) - 2415021.076998695) / 29.530588853))
+	monthStart := newMoonDay(k+1, tz)
+	if monthStart > dayNumber {
+		monthStart = newMoonDay(k, tz)
+	}
+	res := LunarDate{}
+	a11 := lunarMonth11(t.Year(), tz)
+	b11 := a11
+	if a11 >= monthStart {
+		res.Year = t.Year()
+		a11 = lunarMonth11(t.Year()-1, tz)
+	} else {
+		res.Year = t.Year() + 1
+		b11 = lunarMonth11(t.Year()+1, tz)
+	}
+	res.Day = dayNumber - monthStart + 1
+	diff := (monthStart - a11) / 29
+	res.Month = diff + 11
+	if b11-a11 > 365 {
+		leapMonthDiff := leapMonthOffset(a11, tz)
+		if diff >= leapMonthDiff {
+			res.Month = diff + 10
+			res.Leap = diff == leapMonthDiff
+		}
+	}
+	if res.Month > 12 {
+		res.Month -= 12
+	}
+	if res.Month >= 11 && diff < 4 {
+		res.Year--
+	}
+	return res
+}
+
+// jdFromDate returns the Julian day number of a civil date.
+func jdFromDate(dd, mm, yy int) int {
+	a := (14 - mm) / 12
+	y := yy + 4800 - a
+	m := mm + 12*a - 3
+	jd := dd + (153*m+2)/5 + 365*y + y/4 - y/100 + y/400 - 32045
+	if jd < 2299161 {
+		jd = dd + (153*m+2)/5 + 365*y + y/4 - 32083
+	}
+	return jd
+}
+
+// newMoonDay returns the Julian day number of the kth new moon after
+// 1900-01-01.
+func newMoonDay(k int, tz float64) int {
+	kf := float64(k)
+	t := kf / 1236.85
+	t2 := t * t
+	t3 := t2 * t
+	dr := math.Pi / 180
+	jd1 := 2415020.75933 + 29.53058868*kf + 0.0001178*t2 - 0.000000155*t3
+	jd1 += 0.00033 * math.Sin((166.56+132.87*t-0.009173*t2)*dr)
+	m := 359.2242 + 29.10535608*kf - 0.0000333*t2 - 0.00000347*t3
+	mpr := 306.0253 + 385.81691806*kf + 0.0107306*t2 + 0.00001236*t3
+	f := 21.2964 + 390.67050646*kf - 0.0016528*t2 - 0.00000239*t3
+	c1 := (0.1734-0.000393*t)*math.Sin(m*dr) + 0.0021*math.Sin(2*dr*m)
+	c1 = c1 - 0.4068*math.Sin(mpr*dr) + 0.0161*math.Sin(dr*2*mpr)
+	c1 = c1 - 0.0004*math.Sin(dr*3*mpr)
+	c1 = c1 + 0.0104*math.Sin(dr*2*f) - 0.0051*math.Sin(dr*(m+mpr))
+	c1 = c1 - 0.0074*math.Sin(dr*(m-mpr)) + 0.0004*math.Sin(dr*(2*f+m))
+	c1 = c1 - 0.0004*math.Sin(dr*(2*f-m)) - 0.0006*math.Sin(dr*(2*f+mpr))
+	c1 = c1 + 0.0010*math.Sin(dr*(2*f-mpr)) + 0.0005*math.Sin(dr*(2*mpr+m))
+	var deltat float64
+	if t < -11 {
+		deltat = 0.001 + 0.000839*t + 0.0002261*t2 - 0.00000845*t3 - 0.000000081*t*t3
+	} else {
+		deltat = -0.000278 + 0.000265*t + 0.000262*t2
+	}
+	return int(math.Floor(jd1 + c1 - deltat + 0.5 + tz/24))
+}
+
+// sunLongitude returns the sector (0-11) of the sun's longitude at the
+// start of the given day.
+func sunLongitude(jdn int, tz float64) int {
+	t := (float64(jdn) - 2451545.5 - tz/24) / 36525
+	t2 := t * t
+	dr := math.Pi / 180
+	m := 357.52910 + 35999.05030*t - 0.0001559*t2 - 0.00000048*t*t2
+	l0 := 280.46645 + 36000.76983*t + 0.0003032*t2
+	dl := (1.914600 - 0.004817*t - 0.000014*t2) * math.Sin(dr*m)
+	dl = dl + (0.019993-0.000101*t)*math.Sin(dr*2*m) + 0.000290*math.Sin(dr*3*m)
+	l := (l0 + dl) * dr
+	l = l - math.Pi*2*math.Floor(l/(math.Pi*2))
+	return int(math.Floor(l / math.Pi * 6))
+}
+
+// lunarMonth11 returns the first day of the 11th lunar month of year.
+func lunarMonth11(year int, tz float64) int {
+	off := float64(jdFromDate(31, 12, year) - 2415021)
+	k := int(math.Floor(off / 29.530588853))
+	nm := newMoonDay(k, tz)
+	if sunLongitude(nm, tz) >= 9 {
+		nm = newMoonDay(k-1, tz)
+	}
+	return nm
+}
+
+// leapMonthOffset returns the index of the leap month after the 11th
+// lunar month starting at a11.
+func leapMonthOffset(a11 int, tz float64) int {
+	k := int(math.Floor((float64(a11)-2415021.076998695)/29.530588853 + 0.5))
+	i := 1
+	arc := sunLongitude(newMoonDay(k+i, tz), tz)
+	last := arc + 1
+	for arc != last && i < 14 {
+		last = arc
+		i++
+		arc = sunLongitude(newMoonDay(k+i, tz), tz)
+	}
+	return i - 1
+}
+
+func (s *Schedule) lunarZone() int {
+	if s.LunarZone == 0 {
+		return LunarVietnam
+	}
+	return s.LunarZone
+}
+
+// lunarMatch reports whether date matches LunarMonthField and LunarDayField.
+// Leap months only match a restricted LunarMonthField when LunarLeapMonth
+// is set.
+func (s *Schedule) lunarMatch(date time.Time) bool {
+	l := Lunar(date, s.lunarZone())
+	if len(s.LunarMonthField) > 0 {
+		if l.Leap && !s.LunarLeapMonth || !s.LunarMonthField.Match(l.Month) {
+			return false
+		}
+	}
+	return len(s.LunarDayField) == 0 || s.LunarDayField.Match(l.Day)
+}
+
+// lunarPool returns the days of month matching the lunar fields.
+func (s *Schedule) lunarPool(year int, month time.Month) []int {
+	pool := make([]int, 0)
+	for i := 1; i <= maxDay(year, month); i++ {
+		if s.lunarMatch(time.Date(year, month, i, 0, 0, 0, 0, s.Loc)) {
+			pool = append(pool, i)
+		}
+	}
+	return pool
+}
+
+func (s *Schedule) lunarString() string {
+	b := strings.Builder{}
+	if s.LunarMonthField != nil {
+		b.WriteString(s.LunarMonthField.String("lunar month"))
+		if s.LunarLeapMonth {
+			b.WriteString(" including leap months")
+		}
+	}
+	if s.LunarDayField != nil {
+		if s.LunarMonthField != nil {
+			b.WriteString(", ")
+		}
+		b.WriteString(s.LunarDayField.String("lunar day"))
+	}
+	return b.String()
+}
@@ -0,0 +1,46 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLunar(t *testing.T) {
+	// Tet
+	assert.Equal(t, LunarDate{Year: 2024, Month: 1, Day: 1}, Lunar(time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC), LunarVietnam))
+	assert.Equal(t, LunarDate{Year: 2025, Month: 1, Day: 1}, Lunar(time.Date(2025, 1, 29, 0, 0, 0, 0, time.UTC), LunarVietnam))
+	assert.Equal(t, LunarDate{Year: 2023, Month: 12, Day: 30}, Lunar(time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC), LunarVietnam))
+	// leap month
+	assert.Equal(t, LunarDate{Year: 2023, Month: 2, Day: 1, Leap: true}, Lunar(time.Date(2023, 3, 22, 0, 0, 0, 0, time.UTC), LunarVietnam))
+	assert.Equal(t, LunarDate{Year: 2023, Month: 2, Day: 1}, Lunar(time.Date(2023, 2, 20, 0, 0, 0, 0, time.UTC), LunarVietnam))
+	// zone
+	assert.Equal(t, LunarDate{Year: 1985, Month: 1, Day: 1}, Lunar(time.Date(1985, 1, 21, 0, 0, 0, 0, time.UTC), LunarVietnam))
+	assert.Equal(t, LunarDate{Year: 1985, Month: 1, Day: 1}, Lunar(time.Date(1985, 2, 20, 0, 0, 0, 0, time.UTC), LunarChina))
+}
+
+func TestSchedule_Lunar(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*60*60)
+	// every lunar 1st and 15th at 06:00
+	s := Scheduler().WithLoc(loc).LunarDay(At(1), At(15)).Hour(At(6)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 2, 10, 6, 0, 0, 0, loc)), s.Next(time.Date(2024, 2, 1, 0, 0, 0, 0, loc)))
+	assert.Equal(t, ptr(time.Date(2024, 2, 24, 6, 0, 0, 0, loc)), s.NextAfter(time.Date(2024, 2, 10, 6, 0, 0, 0, loc)))
+	assert.Equal(t, ptr(time.Date(2024, 1, 25, 6, 0, 0, 0, loc)), s.Previous(time.Date(2024, 2, 10, 0, 0, 0, 0, loc)))
+	assert.Equal(t, "at 1st lunar day and at 15th lunar day, at 6th hour, at 0th minute, at 0th second", s.String())
+
+	// lunar new year day 1-3
+	s = Scheduler().WithLoc(loc).LunarMonth(At(1)).LunarDay(From(1).To(3)).WithDuration(24 * time.Hour).
+		Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2025, 1, 29, 0, 0, 0, 0, loc)), s.Next(time.Date(2024, 3, 1, 0, 0, 0, 0, loc)))
+	assert.Equal(t, ptr(time.Date(2024, 2, 12, 0, 0, 0, 0, loc)), s.Previous(time.Date(2024, 3, 1, 0, 0, 0, 0, loc)))
+	assert.True(t, s.InProgress(time.Date(2024, 2, 12, 12, 0, 0, 0, loc)))
+	assert.False(t, s.InProgress(time.Date(2024, 2, 13, 12, 0, 0, 0, loc)))
+	assert.Equal(t, "at 1st lunar month, from 1st lunar day through 3rd lunar day, at 0th hour, at 0th minute, at 0th second with 24h0m0s duration", s.String())
+
+	// leap months
+	s = Scheduler().WithLoc(loc).LunarMonth(At(2)).LunarDay(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 3, 10, 0, 0, 0, 0, loc)), s.NextAfter(time.Date(2023, 2, 20, 0, 0, 0, 0, loc)))
+	s.WithLunarLeapMonth(true)
+	assert.Equal(t, ptr(time.Date(2023, 3, 22, 0, 0, 0, 0, loc)), s.NextAfter(time.Date(2023, 2, 20, 0, 0, 0, 0, loc)))
+	assert.Equal(t, "at 2nd lunar month including leap months, at 1st lunar day, at 0th hour, at 0th minute, at 0th second", s.String())
+}
@@ -0,0 +1,131 @@
+package timewalk
+
+import "time"
+
+// Occurrence is a time a schedule occurs at.
+type Occurrence struct {
+	Schedule *Schedule
+	Time     time.Time
+}
+
+// Zoned returns the occurrence in the schedule's location.
+func (o Occurrence) Zoned() time.Time {
+	return o.Time.In(o.Schedule.Loc)
+}
+
+func (o Occurrence) UTC() time.Time {
+	return o.Time.UTC()
+}
+
+func (o Occurrence) In(loc *time.Location) Occurrence {
+	o.Time = o.Time.In(loc)
+	return o
+}
+
+// Window is the span an occurrence is in progress for, from Start up to,
+// but not including, End.
+type Window struct {
+	Schedule *Schedule
+	Start    time.Time
+	End      time.Time
+}
+
+func (w Window) In(loc *time.Location) Window {
+	w.Start = w.Start.In(loc)
+	w.End = w.End.In(loc)
+	return w
+}
+
+// Contains reports whether t falls within the window.
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Overlaps reports whether both windows share an instant, whatever zones
+// they are expressed in.
+func (w Window) Overlaps(o Window) bool {
+	return w.Start.Before(o.End) && o.Start.Before(w.End)
+}
+
+// Between returns the occurrences from from up to, but not including, to.
+// Times are expressed in the schedule's location.
+func (s *Schedule) Between(from, to time.Time) []time.Time {
+	res := make([]time.Time, 0)
+	for occ := s.Next(from); occ != nil && occ.Before(to); occ = s.NextAfter(*occ) {
+		res = append(res, *occ)
+	}
+	return res
+}
+
+// Windows returns the windows of the occurrences in progress at some point
+// from from up to to, clipped to StartTime and EndTime.
+func (s *Schedule) Windows(from, to time.Time) []Window {
+	s.once.Do(s.correct)
+	res := make([]Window, 0)
+	for _, occ := range s.Between(from.Add(-s.Duration), to) {
+		w := s.window(occ)
+		if w.End.Before(w.Start) || s.Duration > 0 && !w.End.After(from) {
+			continue
+		}
+		res = append(res, w)
+	}
+	return res
+}
+
+// WindowsAt returns the windows of all the occurrences in progress at t,
+// latest first, clipped to StartTime and EndTime. There is more than one
+// when occurrences are closer together than Duration.
+func (s *Schedule) WindowsAt(t time.Time) []Window {
+	s.once.Do(s.correct)
+	res := make([]Window, 0)
+	if !s.InProgress(t) {
+		return res
+	}
+	for _, occ := range s.covering(t, 0) {
+		res = append(res, s.window(occ))
+	}
+	return res
+}
+
+// latestWindowAt returns the window in progress at t ending last, if any.
+func (s *Schedule) latestWindowAt(t time.Time) *Window {
+	s.once.Do(s.correct)
+	if !s.InProgress(t) {
+		return nil
+	}
+	// windows all last Duration, the latest to start ends last
+	occ := s.covering(t, 1)
+	if len(occ) == 0 {
+		return nil
+	}
+	return ptr(s.window(occ[0]))
+}
+
+// nextOpen returns the first instant after t the schedule goes in progress
+// at, if it is not at t.
+func (s *Schedule) nextOpen(t time.Time) *time.Time {
+	s.once.Do(s.correct)
+	occ := s.NextAfter(t)
+	if s.StartTime != nil && s.StartTime.After(t) && (occ == nil || occ.Before(*s.StartTime)) {
+		if s.InProgress(*s.StartTime) {
+			return ptr(s.StartTime.In(s.Loc))
+		}
+		occ = s.Next(*s.StartTime)
+	}
+	if occ == nil || !s.InProgress(*occ) {
+		return nil
+	}
+	return occ
+}
+
+// window returns the window of the occurrence at occ.
+func (s *Schedule) window(occ time.Time) Window {
+	w := Window{Schedule: s, Start: occ, End: occ.Add(s.Duration)}
+	if s.StartTime != nil && w.Start.Before(*s.StartTime) {
+		w.Start = s.StartTime.In(s.Loc)
+	}
+	if s.EndTime != nil && w.End.After(*s.EndTime) {
+		w.End = s.EndTime.In(s.Loc)
+	}
+	return w
+}
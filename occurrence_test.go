@@ -0,0 +1,82 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedule_NextZone(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+	s := Scheduler().WithLoc(hcm).Hour(At(9)).Minute(At(0)).Second(At(0))
+	next := s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, hcm, next.Location())
+	assert.Equal(t, time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), next.UTC())
+	prev := s.Previous(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, hcm, prev.Location())
+
+	o := Occurrence{Schedule: s, Time: *next}.In(time.UTC)
+	assert.Equal(t, time.UTC, o.Time.Location())
+	assert.Equal(t, *next, o.Zoned())
+	assert.Equal(t, next.UTC(), o.UTC())
+}
+
+func TestSchedule_Between(t *testing.T) {
+	s := Scheduler().WithLoc(time.UTC).Hour(At(9), At(17)).Minute(At(0)).Second(At(0))
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC),
+	}, s.Between(from, from.AddDate(0, 0, 1)))
+	assert.Equal(t, []time.Time{}, s.Between(from, from.Add(9*time.Hour)))
+}
+
+func TestSchedule_Windows(t *testing.T) {
+	s := Scheduler().WithLoc(time.UTC).Hour(At(9), At(17)).Minute(At(0)).Second(At(0)).WithDuration(2 * time.Hour)
+	from := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	ws := s.Windows(from, from.AddDate(0, 0, 1))
+	assert.Len(t, ws, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), ws[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), ws[0].End)
+	assert.True(t, ws[0].Contains(from))
+	assert.False(t, ws[0].Contains(ws[0].End))
+	assert.Equal(t, time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC), ws[2].Start)
+
+	// clipped to start and end
+	s.StartAt(ptr(time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC))).EndAt(ptr(time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)))
+	ws = s.Windows(from, from.AddDate(0, 0, 1))
+	assert.Len(t, ws, 2)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC), ws[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC), ws[1].End)
+}
+
+func TestWindow_Overlaps(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+	a := Window{Start: time.Date(2024, 1, 1, 9, 0, 0, 0, hcm), End: time.Date(2024, 1, 1, 10, 0, 0, 0, hcm)}
+	b := Window{Start: time.Date(2024, 1, 1, 2, 30, 0, 0, time.UTC), End: time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)}
+	c := Window{Start: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)}
+	assert.True(t, a.Overlaps(b))
+	assert.True(t, b.Overlaps(a))
+	assert.False(t, a.Overlaps(c))
+}
+
+func TestSchedule_WindowsAt(t *testing.T) {
+	s := Scheduler().WithLoc(time.UTC).Minute(At(0)).Second(At(0)).WithDuration(150 * time.Minute)
+	at := time.Date(2024, 1, 1, 10, 20, 0, 0, time.UTC)
+	ws := s.WindowsAt(at)
+	assert.Len(t, ws, 3)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), ws[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), ws[1].Start)
+	assert.Equal(t, time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC), ws[2].Start)
+	assert.Equal(t, time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC), ws[2].End)
+	for _, w := range ws {
+		assert.True(t, w.Contains(at))
+	}
+
+	// windows end before the next occurrence
+	s.WithDuration(30 * time.Minute)
+	assert.Len(t, s.WindowsAt(time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)), 1)
+	assert.Len(t, s.WindowsAt(at.Add(10*time.Minute)), 0)
+}
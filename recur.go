@@ -0,0 +1,79 @@
+package timewalk
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecurUnit is the period anchored recurrences count in.
+type RecurUnit int
+
+const (
+	RecurDay RecurUnit = iota
+	RecurWeek
+	RecurMonth
+	RecurYear
+)
+
+func (u RecurUnit) String() string {
+	switch u {
+	case RecurWeek:
+		return "week"
+	case RecurMonth:
+		return "month"
+	case RecurYear:
+		return "year"
+	default:
+		return "day"
+	}
+}
+
+// Recur restricts the schedule to every nth day, week, month or year
+// counted from the one containing anchor, across month and year
+// boundaries. Weeks start on Monday. The other fields still apply within
+// the matching periods, e.g. every other Tuesday is
+// Recur(anchor, 2, RecurWeek).DayOfWeek(At(time.Tuesday)).
+func (s *Schedule) Recur(anchor time.Time, every int, unit RecurUnit) *Schedule {
+	s.RecurEvery = every
+	s.RecurUnit = unit
+	s.AnchorTime = &anchor
+	s.Anchor = anchor.Unix()
+	return s
+}
+
+// recurIndex returns the number of periods between the epoch of the Julian
+// day number and the given date.
+func (s *Schedule) recurIndex(year int, month time.Month, day int) int {
+	switch s.RecurUnit {
+	case RecurWeek:
+		// Julian day numbers divisible by 7 are Mondays
+		return jdFromDate(day, int(month), year) / 7
+	case RecurMonth:
+		return year*12 + int(month) - 1
+	case RecurYear:
+		return year
+	default:
+		return jdFromDate(day, int(month), year)
+	}
+}
+
+// recurPool returns the days of month in a recurring period.
+func (s *Schedule) recurPool(year int, month time.Month) []int {
+	anchor := s.anchor()
+	from := s.recurIndex(anchor.Year(), anchor.Month(), anchor.Day())
+	pool := make([]int, 0)
+	for i := 1; i <= maxDay(year, month); i++ {
+		if diff := s.recurIndex(year, month, i) - from; (diff%s.RecurEvery+s.RecurEvery)%s.RecurEvery == 0 {
+			pool = append(pool, i)
+		}
+	}
+	return pool
+}
+
+func (s *Schedule) recurString() string {
+	unit := s.RecurUnit.String()
+	if s.RecurEvery != 1 {
+		unit = fmt.Sprint(s.RecurEvery, " ", unit, "s")
+	}
+	return fmt.Sprint("every ", unit, " since ", s.anchor().Format("2006-01-02"))
+}
@@ -0,0 +1,46 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedule_RecurWeek(t *testing.T) {
+	// every other Tuesday at 10:00, 2024-01-02 is a Tuesday
+	anchor := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	s := Scheduler().WithLoc(time.UTC).Recur(anchor, 2, RecurWeek).DayOfWeek(At(time.Tuesday)).
+		Hour(At(10)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, &anchor, s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)), s.NextAfter(anchor))
+	assert.Equal(t, ptr(time.Date(2024, 1, 30, 10, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 1, 16, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 2, 13, 10, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 1, 30, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, &anchor, s.Previous(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2023, 12, 19, 10, 0, 0, 0, time.UTC)), s.PreviousBefore(anchor))
+	// across years
+	assert.Equal(t, ptr(time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 12, 24, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2025, 1, 14, 10, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "every 2 weeks since 2024-01-02, at Tuesday, at 10th hour, at 0th minute, at 0th second", s.String())
+}
+
+func TestSchedule_RecurDay(t *testing.T) {
+	anchor := time.Date(2024, 1, 25, 0, 0, 0, 0, time.UTC)
+	s := Scheduler().WithLoc(time.UTC).Recur(anchor, 10, RecurDay).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)), s.NextAfter(anchor))
+	assert.Equal(t, ptr(time.Date(2024, 2, 14, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)), s.PreviousBefore(anchor))
+	assert.Equal(t, "every 10 days since 2024-01-25, at 0th hour, at 0th minute, at 0th second", s.String())
+}
+
+func TestSchedule_RecurMonthYear(t *testing.T) {
+	anchor := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := Scheduler().WithLoc(time.UTC).Recur(anchor, 3, RecurMonth).Day(At(15)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)), s.Next(anchor))
+	assert.Equal(t, ptr(time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, ptr(time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC)), s.Previous(anchor))
+
+	s.Recur(anchor, 2, RecurYear).Month(At(time.June))
+	assert.Equal(t, ptr(time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)), s.NextAfter(time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "every 2 years since 2024-01-01, at June, at 15th day, at 0th hour, at 0th minute, at 0th second", s.String())
+}
@@ -0,0 +1,103 @@
+package timewalk
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy retries the failed runs of a job. The nth retry waits
+// Backoff doubled n-1 times, capped at MaxBackoff when set, plus a random
+// delay up to Jitter. Retries are abandoned once the next occurrence of the
+// job is due, unless Overlap is set, or once the schedule has ended.
+type RetryPolicy struct {
+	// Attempts is how many times a failed run is retried.
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Jitter     time.Duration
+	Overlap    bool
+}
+
+func WithRetry(policy RetryPolicy) JobOption {
+	return func(e *entry) {
+		e.retry = policy
+	}
+}
+
+func withEnd(s *Schedule) JobOption {
+	return func(e *entry) {
+		s.once.Do(s.correct)
+		e.end = s.EndTime
+	}
+}
+
+// withSchedulersEnd sets the end to the latest end of the enabled schedules
+// of s, none if any of them goes on forever.
+func withSchedulersEnd(s Schedulers) JobOption {
+	return func(e *entry) {
+		e.end = nil
+		for _, v := range s {
+			if !v.Enable {
+				continue
+			}
+			v.once.Do(v.correct)
+			if v.EndTime == nil {
+				e.end = nil
+				return
+			}
+			if e.end == nil || v.EndTime.After(*e.end) {
+				e.end = v.EndTime
+			}
+		}
+	}
+}
+
+// delay returns how long to wait before the nth retry.
+func (p RetryPolicy) delay(n int) time.Duration {
+	res := p.Backoff
+	// stop doubling before it overflows
+	for i := 1; i < n && (p.MaxBackoff <= 0 || res < p.MaxBackoff) && res <= math.MaxInt64/2; i++ {
+		res *= 2
+	}
+	if p.MaxBackoff > 0 && res > p.MaxBackoff {
+		res = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		jitter := time.Duration(rand.Int63n(int64(p.Jitter)))
+		if res > math.MaxInt64-jitter {
+			return math.MaxInt64
+		}
+		res += jitter
+	}
+	return res
+}
+
+// attempt runs e for the occurrence at, retrying as its policy allows, and
+// records each attempt. It returns the error of the last one.
+func (r *Runner) attempt(ctx context.Context, e *entry, at time.Time) error {
+	for n := 1; ; n++ {
+		run := Run{Occurrence: at, Attempt: n, Start: r.clock.Now()}
+		err := call(ctx, e.job)
+		run.End = r.clock.Now()
+		if err != nil {
+			run.Error = err.Error()
+		}
+		r.mu.Lock()
+		r.persist(e, func(store Store) error {
+			return store.AddRun(e.id, run)
+		})
+		retry := r.clock.Now().Add(e.retry.delay(n))
+		abandon := e.end != nil && retry.After(*e.end) ||
+			!e.retry.Overlap && e.at != nil && !retry.Before(*e.at)
+		r.mu.Unlock()
+		r.flush()
+		if err == nil || n > e.retry.Attempts || ctx.Err() != nil || abandon {
+			return err
+		}
+		if sleepUntil(ctx, r.clock, retry) != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{Backoff: time.Second, MaxBackoff: 10 * time.Second}
+	assert.Equal(t, time.Second, p.delay(1))
+	assert.Equal(t, 2*time.Second, p.delay(2))
+	assert.Equal(t, 8*time.Second, p.delay(4))
+	assert.Equal(t, 10*time.Second, p.delay(5))
+	assert.Equal(t, 10*time.Second, p.delay(1000))
+
+	p.Jitter = time.Second
+	for i := 0; i < 10; i++ {
+		d := p.delay(1)
+		assert.True(t, d >= time.Second && d < 2*time.Second, d)
+	}
+
+	// without a cap, doubling stops before it overflows
+	p = RetryPolicy{Backoff: time.Second}
+	assert.Equal(t, 1024*time.Second, p.delay(11))
+	assert.True(t, p.delay(100) > 0)
+	assert.Equal(t, p.delay(100), p.delay(1000))
+	p.Jitter = time.Second
+	assert.True(t, p.delay(1000) > 0)
+}
+
+func TestWithSchedulersEnd(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	a := Scheduler().EndAt(&first)
+	a.Enable = true
+	b := Scheduler().EndAt(&last)
+	b.Enable = true
+	never := Scheduler()
+	e := &entry{}
+	withSchedulersEnd(Schedulers{a, b, never})(e)
+	assert.Equal(t, &last, e.end)
+
+	// goes on forever
+	never.Enable = true
+	withSchedulersEnd(Schedulers{a, b, never})(e)
+	assert.Nil(t, e.end)
+
+	// from JSON
+	var list Schedulers
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`[{"enable":true,"end":%d}]`, last.Unix())), &list))
+	withSchedulersEnd(append(list, a))(e)
+	assert.Equal(t, last.Unix(), e.end.Unix())
+}
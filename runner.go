@@ -0,0 +1,444 @@
+package timewalk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrDuplicateJob = errors.New("timewalk: duplicate job id")
+
+// ConcurrencyPolicy is what a Runner does when a job is due while a run
+// of it is still in progress.
+type ConcurrencyPolicy int
+
+const (
+	// ConcurrencyAllow starts another run alongside.
+	ConcurrencyAllow ConcurrencyPolicy = iota
+	// ConcurrencyForbid skips the occurrence.
+	ConcurrencyForbid
+	// ConcurrencyQueue runs the occurrence once the run in progress returns.
+	// Only one occurrence is queued, further ones are skipped.
+	ConcurrencyQueue
+	// ConcurrencyReplace cancels the run in progress and starts another.
+	ConcurrencyReplace
+)
+
+var concurrencyPolicyNames = map[ConcurrencyPolicy]string{
+	ConcurrencyAllow:   "allow",
+	ConcurrencyForbid:  "forbid",
+	ConcurrencyQueue:   "queue",
+	ConcurrencyReplace: "replace",
+}
+
+func (p ConcurrencyPolicy) String() string {
+	return concurrencyPolicyNames[p]
+}
+
+// JobOption configures a job added to a Runner.
+type JobOption func(*entry)
+
+func WithConcurrency(policy ConcurrencyPolicy) JobOption {
+	return func(e *entry) {
+		e.policy = policy
+	}
+}
+
+// Job is the work a Runner does at each occurrence of its schedule.
+type Job func(ctx context.Context) error
+
+// Entry is the state of a job registered with a Runner.
+type Entry struct {
+	ID string
+	// Next is the next run, nil when the schedule has no occurrence left.
+	Next *time.Time
+	// Prev is the occurrence of the last run started.
+	Prev *time.Time
+	// Err is the error of the last run finished.
+	Err     error
+	Running int
+	Policy  ConcurrencyPolicy
+	// Skipped counts the occurrences skipped as a run was in progress.
+	Skipped int
+	// Replaced counts the runs canceled to start another.
+	Replaced int
+	// CatchUp is what is done with the occurrences missed.
+	CatchUp CatchUpPolicy
+	// Missed counts the occurrences dropped by the catch-up policy.
+	Missed int
+	// StoreErr is the last error persisting the state of the job.
+	StoreErr error
+}
+
+type entry struct {
+	id       string
+	next     func(t time.Time) *time.Time
+	job      Job
+	policy   ConcurrencyPolicy
+	catchUp  CatchUpPolicy
+	deadline time.Duration
+	retry    RetryPolicy
+	// end is when the schedule ends, if it does.
+	end      *time.Time
+	at       *time.Time
+	prev     *time.Time
+	err      error
+	running  int
+	skipped  int
+	replaced int
+	missed   int
+	storeErr error
+	// queued is the occurrence waiting for the run in progress.
+	queued *time.Time
+	// cancel cancels the last run started.
+	cancel context.CancelFunc
+}
+
+func (e *entry) state() Entry {
+	return Entry{
+		ID:       e.id,
+		Next:     e.at,
+		Prev:     e.prev,
+		Err:      e.err,
+		Running:  e.running,
+		Policy:   e.policy,
+		Skipped:  e.skipped,
+		Replaced: e.replaced,
+		CatchUp:  e.catchUp,
+		Missed:   e.missed,
+		StoreErr: e.storeErr,
+	}
+}
+
+// Runner runs jobs at the occurrences of their schedules. Runs start on
+// their own goroutine, so a slow job never delays the others.
+type Runner struct {
+	mu      sync.Mutex
+	clock   Clock
+	store   Store
+	entries []*entry
+	wake    chan struct{}
+	running bool
+	// cancel stops the loop, which closes done.
+	cancel context.CancelFunc
+	done   chan struct{}
+	// jobCancel cancels the context of the runs.
+	jobCancel context.CancelFunc
+	jobs      sync.WaitGroup
+	// writes are the store writes recorded under mu, made by flush once it
+	// is released.
+	writes []write
+	// flushing serializes the flushes, keeping the writes in order.
+	flushing sync.Mutex
+}
+
+func NewRunner(opts ...Option) *Runner {
+	return &Runner{
+		clock: newOptions(opts).clock,
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// WithStore persists the state of the jobs to store. Jobs added afterwards
+// without WithLastRun pick up their last run from it.
+func (r *Runner) WithStore(store Store) *Runner {
+	r.store = store
+	return r
+}
+
+// Add registers job to run at each occurrence of s.
+func (r *Runner) Add(id string, s *Schedule, job Job, opts ...JobOption) error {
+	return r.add(id, s.Next, job, append([]JobOption{withEnd(s)}, opts...))
+}
+
+// AddSchedulers registers job to run at each occurrence of the enabled
+// schedules of s. Retries stop at the latest end of the schedules.
+func (r *Runner) AddSchedulers(id string, s Schedulers, job Job, opts ...JobOption) error {
+	return r.add(id, s.next, job, append([]JobOption{withSchedulersEnd(s)}, opts...))
+}
+
+func (r *Runner) add(id string, next func(t time.Time) *time.Time, job Job, opts []JobOption) error {
+	defer r.flush()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.find(id) != nil {
+		return fmt.Errorf("%w: %q", ErrDuplicateJob, id)
+	}
+	e := &entry{id: id, next: next, job: job}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if r.store != nil && e.prev == nil {
+		last, err := r.store.LastRun(id)
+		if err != nil {
+			return err
+		}
+		e.prev = last
+	}
+	e.reset(r.clock.Now())
+	r.saveNext(e)
+	r.entries = append(r.entries, e)
+	r.poke()
+	return nil
+}
+
+// Remove unregisters the job with id. Runs in progress go on.
+func (r *Runner) Remove(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, e := range r.entries {
+		if e.id == id {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			break
+		}
+	}
+	r.poke()
+}
+
+// Entries returns the state of the jobs, in the order they were added.
+func (r *Runner) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]Entry, 0, len(r.entries))
+	for _, e := range r.entries {
+		res = append(res, e.state())
+	}
+	return res
+}
+
+// Entry returns the state of the job with id, or nil if there is none.
+func (r *Runner) Entry(id string) *Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if e := r.find(id); e != nil {
+		return ptr(e.state())
+	}
+	return nil
+}
+
+func (r *Runner) find(id string) *entry {
+	for _, e := range r.entries {
+		if e.id == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// Start runs the jobs in the background until Stop is called or ctx is
+// done. Runs get a context derived from ctx. Starting a running Runner does
+// nothing.
+func (r *Runner) Start(ctx context.Context) {
+	defer r.flush()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.running {
+		return
+	}
+	r.running = true
+	now := r.clock.Now()
+	for _, e := range r.entries {
+		e.reset(now)
+		r.saveNext(e)
+	}
+	var loopCtx context.Context
+	loopCtx, r.cancel = context.WithCancel(ctx)
+	var jobCtx context.Context
+	jobCtx, r.jobCancel = context.WithCancel(ctx)
+	r.done = make(chan struct{})
+	go r.loop(loopCtx, jobCtx, r.done)
+}
+
+// Stop stops starting runs and waits for those in progress to return. If
+// ctx is done first, their context is canceled and Stop returns ctx.Err()
+// without waiting any longer.
+func (r *Runner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return nil
+	}
+	r.running = false
+	cancel, done, jobCancel := r.cancel, r.done, r.jobCancel
+	r.mu.Unlock()
+
+	cancel()
+	<-done
+	finished := make(chan struct{})
+	go func() {
+		r.jobs.Wait()
+		close(finished)
+	}()
+	defer jobCancel()
+	select {
+	case <-finished:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Runner) loop(ctx, jobCtx context.Context, done chan struct{}) {
+	defer close(done)
+	for {
+		wait := r.runDue(jobCtx, r.clock.Now())
+		var timer Timer
+		var fire <-chan time.Time
+		if wait != nil {
+			timer = r.clock.NewTimer(*wait)
+			fire = timer.C()
+		}
+		select {
+		case <-ctx.Done():
+		case <-fire:
+		case <-r.wake:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// runDue starts the runs due at now and returns how long until the next
+// one, or nil when there is none.
+func (r *Runner) runDue(ctx context.Context, now time.Time) *time.Duration {
+	defer r.flush()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var wait *time.Duration
+	for _, e := range r.entries {
+		if e.at != nil && !e.at.After(now) {
+			for _, at := range e.due(now) {
+				r.run(ctx, e, at)
+			}
+			r.saveNext(e)
+		}
+		if e.at != nil && (wait == nil || e.at.Sub(now) < *wait) {
+			wait = ptr(e.at.Sub(now))
+		}
+	}
+	return wait
+}
+
+// run handles the occurrence at of e following its concurrency policy.
+func (r *Runner) run(ctx context.Context, e *entry, at time.Time) {
+	if e.running > 0 {
+		switch e.policy {
+		case ConcurrencyForbid:
+			e.skipped++
+			return
+		case ConcurrencyQueue:
+			if e.queued != nil {
+				e.skipped++
+			} else {
+				e.queued = &at
+			}
+			return
+		case ConcurrencyReplace:
+			e.cancel()
+			e.replaced++
+		}
+	}
+	r.start(ctx, e, at)
+}
+
+// start starts a run of e for the occurrence at.
+func (r *Runner) start(ctx context.Context, e *entry, at time.Time) {
+	runCtx, cancel := context.WithCancel(ctx)
+	e.prev = &at
+	e.running++
+	e.cancel = cancel
+	r.persist(e, func(store Store) error {
+		return store.SetLastRun(e.id, at)
+	})
+	r.jobs.Add(1)
+	go func() {
+		defer r.jobs.Done()
+		err := r.attempt(runCtx, e, at)
+		cancel()
+		defer r.flush()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		e.running--
+		e.err = err
+		if e.queued != nil && e.running == 0 {
+			queued := *e.queued
+			e.queued = nil
+			if r.running && ctx.Err() == nil {
+				r.start(ctx, e, queued)
+			}
+		}
+	}()
+}
+
+// call runs job, turning a panic into an error.
+func call(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("timewalk: job panicked: %v", v)
+		}
+	}()
+	return job(ctx)
+}
+
+func (r *Runner) saveNext(e *entry) {
+	at := e.at
+	r.persist(e, func(store Store) error {
+		return store.SetNextRun(e.id, at)
+	})
+}
+
+// write is a write of the state of a job to the store.
+type write struct {
+	e  *entry
+	fn func(store Store) error
+}
+
+// persist records a write of the state of e to the store, if any. It is
+// called with mu held, the write is made by the next flush.
+func (r *Runner) persist(e *entry, fn func(store Store) error) {
+	if r.store == nil {
+		return
+	}
+	r.writes = append(r.writes, write{e: e, fn: fn})
+}
+
+// flush makes the writes recorded, keeping the error of the last write of
+// each job. It is called once mu is released, so that the store never holds
+// up the runner.
+func (r *Runner) flush() {
+	r.flushing.Lock()
+	defer r.flushing.Unlock()
+	r.mu.Lock()
+	writes := r.writes
+	r.writes = nil
+	r.mu.Unlock()
+	for _, w := range writes {
+		err := w.fn(r.store)
+		r.mu.Lock()
+		w.e.storeErr = err
+		r.mu.Unlock()
+	}
+}
+
+// poke wakes the loop up to pick up changes to the entries.
+func (r *Runner) poke() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ceil rounds t up to the second.
+func ceil(t time.Time) time.Time {
+	res := t.Truncate(time.Second)
+	if res.Before(t) {
+		res = res.Add(time.Second)
+	}
+	return res
+}
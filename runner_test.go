@@ -0,0 +1,257 @@
+package timewalk_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/vuho-pg/timewalk"
+	"github.com/vuho-pg/timewalk/timewalktest"
+	"testing"
+	"time"
+)
+
+func TestRunner(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := timewalktest.NewFakeClock(start)
+	r := timewalk.NewRunner(timewalk.WithClock(clock))
+	runs := make(chan time.Time, 10)
+	every := timewalk.Scheduler().WithLoc(time.UTC)
+	assert.NoError(t, r.Add("every", every, func(ctx context.Context) error {
+		runs <- clock.Now()
+		return errors.New("failed")
+	}))
+	assert.ErrorIs(t, r.Add("every", every, nil), timewalk.ErrDuplicateJob)
+	never := timewalk.Scheduler().WithLoc(time.UTC).Year(timewalk.At(2000))
+	never.Enable = true
+	assert.NoError(t, r.AddSchedulers("never", timewalk.Schedulers{never}, nil))
+
+	r.Start(context.Background())
+	assert.Equal(t, start, <-runs)
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-runs)
+	assert.NoError(t, r.Stop(context.Background()))
+
+	entries := r.Entries()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "every", entries[0].ID)
+	assert.Equal(t, start.Add(time.Second), *entries[0].Prev)
+	assert.Equal(t, start.Add(2*time.Second), *entries[0].Next)
+	assert.EqualError(t, entries[0].Err, "failed")
+	assert.Equal(t, 0, entries[0].Running)
+	assert.Nil(t, r.Entry("never").Next)
+	r.Remove("never")
+	assert.Nil(t, r.Entry("never"))
+}
+
+func TestRunner_Stop(t *testing.T) {
+	clock := timewalktest.NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	r := timewalk.NewRunner(timewalk.WithClock(clock))
+	started := make(chan struct{}, 10)
+	finished := make(chan struct{})
+	assert.NoError(t, r.Add("slow", timewalk.Scheduler(), func(ctx context.Context) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		return ctx.Err()
+	}))
+	assert.NoError(t, r.Add("panic", timewalk.Scheduler(), func(ctx context.Context) error {
+		defer close(finished)
+		panic("boom")
+	}))
+	r.Start(context.Background())
+	<-started
+	<-finished
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// the slow job only returns once its context is canceled
+	assert.ErrorIs(t, r.Stop(ctx), context.Canceled)
+	assert.NoError(t, r.Stop(context.Background()))
+	assert.Eventually(t, func() bool {
+		return r.Entry("slow").Running == 0
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, r.Entry("slow").Err, context.Canceled)
+	assert.Eventually(t, func() bool {
+		return r.Entry("panic").Running == 0
+	}, time.Second, time.Millisecond)
+	assert.EqualError(t, r.Entry("panic").Err, "timewalk: job panicked: boom")
+}
+
+func TestRunner_Concurrency(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := timewalktest.NewFakeClock(start)
+	canceled := make(chan struct{}, 10)
+	block := func(release chan struct{}, started chan struct{}) timewalk.Job {
+		return func(ctx context.Context) error {
+			if started != nil {
+				started <- struct{}{}
+			}
+			select {
+			case <-release:
+			case <-ctx.Done():
+				canceled <- struct{}{}
+			}
+			return nil
+		}
+	}
+	release := make(chan struct{})
+	queued := make(chan struct{})
+	queueStarted := make(chan struct{}, 10)
+	r := timewalk.NewRunner(timewalk.WithClock(clock))
+	assert.NoError(t, r.Add("allow", timewalk.Scheduler().WithLoc(time.UTC), block(release, nil)))
+	assert.NoError(t, r.Add("forbid", timewalk.Scheduler().WithLoc(time.UTC), block(release, nil), timewalk.WithConcurrency(timewalk.ConcurrencyForbid)))
+	assert.NoError(t, r.Add("queue", timewalk.Scheduler().WithLoc(time.UTC), block(queued, queueStarted), timewalk.WithConcurrency(timewalk.ConcurrencyQueue)))
+	assert.NoError(t, r.Add("replace", timewalk.Scheduler().WithLoc(time.UTC), block(release, nil), timewalk.WithConcurrency(timewalk.ConcurrencyReplace)))
+	r.Start(context.Background())
+	<-queueStarted
+
+	// three occurrences while the first run is blocked
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+	}
+	clock.BlockUntil(1)
+	assert.Equal(t, 3, r.Entry("allow").Running)
+	forbid := r.Entry("forbid")
+	assert.Equal(t, 1, forbid.Running)
+	assert.Equal(t, 2, forbid.Skipped)
+	queue := r.Entry("queue")
+	assert.Equal(t, 1, queue.Running)
+	assert.Equal(t, 1, queue.Skipped)
+	assert.Equal(t, start, *queue.Prev)
+	replace := r.Entry("replace")
+	assert.Equal(t, 2, replace.Replaced)
+	<-canceled
+	<-canceled
+	assert.Equal(t, timewalk.ConcurrencyReplace, replace.Policy)
+	assert.Equal(t, "replace", replace.Policy.String())
+
+	// the queued occurrence runs once the run in progress returns
+	queued <- struct{}{}
+	<-queueStarted
+	queue = r.Entry("queue")
+	assert.Equal(t, 1, queue.Running)
+	assert.Equal(t, start.Add(time.Second), *queue.Prev)
+	close(release)
+	close(queued)
+	assert.NoError(t, r.Stop(context.Background()))
+}
+
+func TestRunner_CatchUp(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	clock := timewalktest.NewFakeClock(start)
+	r := timewalk.NewRunner(timewalk.WithClock(clock))
+	hourly := timewalk.Scheduler().WithLoc(time.UTC).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	runs := make(chan string, 10)
+	job := func(id string) timewalk.Job {
+		return func(ctx context.Context) error {
+			runs <- id
+			return nil
+		}
+	}
+	// missed 08:00, 09:00 and 10:00
+	last := start.Add(-3 * time.Hour)
+	assert.NoError(t, r.Add("all", hourly, job("all"), timewalk.WithCatchUp(timewalk.CatchUpAll, 0), timewalk.WithLastRun(last)))
+	assert.NoError(t, r.Add("deadline", hourly, job("deadline"), timewalk.WithCatchUp(timewalk.CatchUpAll, 2*time.Hour), timewalk.WithLastRun(last)))
+	r.Start(context.Background())
+	clock.BlockUntil(1)
+	assert.NoError(t, r.Stop(context.Background()))
+	close(runs)
+	count := map[string]int{}
+	for id := range runs {
+		count[id]++
+	}
+	assert.Equal(t, map[string]int{"all": 3, "deadline": 2}, count)
+
+	e := r.Entry("all")
+	assert.Equal(t, 0, e.Missed)
+	assert.Equal(t, start.Add(30*time.Minute), *e.Next)
+	assert.Equal(t, start.Add(-30*time.Minute), *e.Prev)
+	assert.Equal(t, timewalk.CatchUpAll, e.CatchUp)
+	assert.Equal(t, 1, r.Entry("deadline").Missed)
+}
+
+func TestRunner_Retry(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := timewalktest.NewFakeClock(start)
+	store := timewalk.NewMemoryStore()
+	r := timewalk.NewRunner(timewalk.WithClock(clock)).WithStore(store)
+	daily := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(0)).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	attempts := make(chan time.Time, 10)
+	assert.NoError(t, r.Add("daily", daily, func(ctx context.Context) error {
+		attempts <- clock.Now()
+		return errors.New("failed")
+	}, timewalk.WithRetry(timewalk.RetryPolicy{Attempts: 3, Backoff: 10 * time.Minute, MaxBackoff: 20 * time.Minute})))
+	r.Start(context.Background())
+	assert.Equal(t, start, <-attempts)
+
+	// retried after 10, 20 then 20 minutes
+	for _, at := range []time.Duration{10 * time.Minute, 30 * time.Minute, 50 * time.Minute} {
+		// the runner waits for the next day, the job for its retry
+		clock.BlockUntil(2)
+		clock.Set(start.Add(at))
+		assert.Equal(t, start.Add(at), <-attempts)
+	}
+	assert.NoError(t, r.Stop(context.Background()))
+	history, err := store.History("daily")
+	assert.NoError(t, err)
+	assert.Len(t, history, 4)
+	for i, run := range history {
+		assert.Equal(t, i+1, run.Attempt)
+		assert.Equal(t, start, run.Occurrence)
+	}
+}
+
+func TestRunner_RetryAbandoned(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := timewalktest.NewFakeClock(start)
+	store := timewalk.NewMemoryStore()
+	r := timewalk.NewRunner(timewalk.WithClock(clock)).WithStore(store)
+	failed := errors.New("failed")
+	attempts := make(chan time.Time, 10)
+	// once, ending 90 minutes later
+	end := start.Add(90 * time.Minute)
+	once := timewalk.Scheduler().WithLoc(time.UTC).Year(timewalk.At(2024)).Month(timewalk.At(time.January)).Day(timewalk.At(1)).
+		Hour(timewalk.At(0)).Minute(timewalk.At(0)).Second(timewalk.At(0)).EndAt(&end)
+	once.Enable = true
+	assert.NoError(t, r.AddSchedulers("end", timewalk.Schedulers{once}, func(ctx context.Context) error {
+		attempts <- clock.Now()
+		return failed
+	}, timewalk.WithRetry(timewalk.RetryPolicy{Attempts: 3, Backoff: time.Hour})))
+	// retrying after 2 days would overlap the next occurrence
+	daily := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(12)).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	overlaps := make(chan time.Time, 10)
+	assert.NoError(t, r.Add("overlap", daily, func(ctx context.Context) error {
+		overlaps <- clock.Now()
+		return failed
+	}, timewalk.WithRetry(timewalk.RetryPolicy{Attempts: 3, Backoff: 48 * time.Hour})))
+	r.Start(context.Background())
+	assert.Equal(t, start, <-attempts)
+
+	// retried after an hour, the retry after 2 more hours is past the end
+	clock.BlockUntil(2)
+	clock.Set(start.Add(time.Hour))
+	assert.Equal(t, start.Add(time.Hour), <-attempts)
+	for _, at := range []time.Time{start.Add(12 * time.Hour), start.Add(36 * time.Hour)} {
+		clock.BlockUntil(1)
+		clock.Set(at)
+		assert.Equal(t, at, <-overlaps)
+	}
+	assert.NoError(t, r.Stop(context.Background()))
+	assert.Len(t, attempts, 0)
+	assert.Len(t, overlaps, 0)
+
+	history, err := store.History("end")
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, 2, history[1].Attempt)
+	assert.Equal(t, "failed", history[1].Error)
+	assert.Equal(t, history[0].Occurrence, history[1].Occurrence)
+	assert.Equal(t, failed, r.Entry("end").Err)
+
+	history, err = store.History("overlap")
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	for _, run := range history {
+		assert.Equal(t, 1, run.Attempt)
+	}
+}
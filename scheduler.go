@@ -18,24 +18,61 @@ var (
 	AnySecond    = Field[int](From(0))
 )
 
+// DayMode controls how DayField and DayOfWeekField combine when both are set.
+type DayMode int
+
+const (
+	// DayModeAnd requires a day to match both DayField and DayOfWeekField.
+	DayModeAnd DayMode = iota
+	// DayModeOr accepts a day matching either DayField or DayOfWeekField, like cron.
+	DayModeOr
+)
+
 type Schedule struct {
-	once           sync.Once
-	Enable         bool                 `json:"enable"`
-	YearField      TField[int]          `json:"year"`
-	MonthField     TField[time.Month]   `json:"month"` //1-12
-	WeekField      TField[int]          `json:"week"`
-	DayField       TField[int]          `json:"day"`         //1-31
-	DayOfWeekField TField[time.Weekday] `json:"day_of_week"` //0-6
-	HourField      TField[int]          `json:"hour"`        //0-23
-	MinuteField    TField[int]          `json:"minute"`      //0-59
-	SecondField    TField[int]          `json:"second"`      //0-59
-	Duration       time.Duration        `json:"duration"`
-	Start          int64                `json:"start"`
-	End            int64                `json:"end"`
-	Location       string               `json:"location"`
-	StartTime      *time.Time           `json:"-"`
-	EndTime        *time.Time           `json:"-"`
-	Loc            *time.Location       `json:"-"`
+	once               sync.Once
+	Enable             bool                 `json:"enable"`
+	YearField          TField[int]          `json:"year"`
+	MonthField         TField[time.Month]   `json:"month"` //1-12
+	WeekField          TField[int]          `json:"week"`
+	DayField           TField[int]          `json:"day"`         //1-31
+	DayOfWeekField     TField[time.Weekday] `json:"day_of_week"` //0-6
+	HourField          TField[int]          `json:"hour"`        //0-23
+	MinuteField        TField[int]          `json:"minute"`      //0-59
+	SecondField        TField[int]          `json:"second"`      //0-59
+	DayMode            DayMode              `json:"day_mode"`
+	BusinessDayField   TField[int]          `json:"business_day"`
+	BusinessDayPeriod  Period               `json:"business_day_period"`
+	BusinessDayFromEnd bool                 `json:"business_day_from_end"`
+	FeastField         []Feast              `json:"feast"`
+	LunarMonthField    TField[int]          `json:"lunar_month"` //1-12
+	LunarDayField      TField[int]          `json:"lunar_day"`   //1-30
+	LunarLeapMonth     bool                 `json:"lunar_leap_month"`
+	LunarZone          int                  `json:"lunar_zone"`
+	Fiscal             *FiscalCalendar      `json:"fiscal,omitempty"`
+	FiscalYearField    TField[int]          `json:"fiscal_year"`
+	FiscalPeriodField  TField[int]          `json:"fiscal_period"`
+	FiscalWeekField    TField[int]          `json:"fiscal_week"` //1-53
+	FiscalDayField     TField[int]          `json:"fiscal_day"`
+	FiscalDayFromEnd   bool                 `json:"fiscal_day_from_end"`
+	Shift              ShiftPolicy          `json:"shift"`
+	DSTGap             DSTGap               `json:"dst_gap"`
+	DSTOverlap         DSTOverlap           `json:"dst_overlap"`
+	Interval           time.Duration        `json:"interval"`
+	RecurEvery         int                  `json:"recur_every"`
+	RecurUnit          RecurUnit            `json:"recur_unit"`
+	Solar              *SolarEvent          `json:"solar,omitempty"`
+	Anchor             int64                `json:"anchor"`
+	Duration           time.Duration        `json:"duration"`
+	Jitter             time.Duration        `json:"jitter"`
+	JitterSeed         int64                `json:"jitter_seed"`
+	Start              int64                `json:"start"`
+	End                int64                `json:"end"`
+	Location           string               `json:"location"`
+	StartTime          *time.Time           `json:"-"`
+	EndTime            *time.Time           `json:"-"`
+	AnchorTime         *time.Time           `json:"-"`
+	Loc                *time.Location       `json:"-"`
+	Calendars          []Calendar           `json:"-"`
 }
 
 func Scheduler() *Schedule {
@@ -53,14 +90,85 @@ func (s *Schedule) correct() {
 	if s.End != 0 {
 		s.EndTime = ptr(time.Unix(s.End, 0))
 	}
+	if s.Anchor != 0 {
+		s.AnchorTime = ptr(time.Unix(s.Anchor, 0))
+	}
 	s.WithLocString(s.Location)
 }
 
-func ScheduleFromJSON(data string) (*Schedule, error) {
+// scheduleFields has the fields of Schedule without its JSON methods.
+type scheduleFields Schedule
+
+// scheduleJSON is the JSON form of a Schedule, with its calendars.
+type scheduleJSON struct {
+	*scheduleFields
+	Calendars []*MemoryCalendar `json:"calendars,omitempty"`
+}
+
+// MarshalJSON encodes s along with its calendars. Only calendars of type
+// *MemoryCalendar can be encoded, others make it fail.
+func (s *Schedule) MarshalJSON() ([]byte, error) {
+	calendars, err := encodeCalendars(s.Calendars)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(scheduleJSON{scheduleFields: (*scheduleFields)(s), Calendars: calendars})
+}
+
+func (s *Schedule) UnmarshalJSON(data []byte) error {
+	v := scheduleJSON{scheduleFields: (*scheduleFields)(s)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	calendars, err := decodeCalendars(v.Calendars)
+	if err != nil {
+		return err
+	}
+	s.Calendars = calendars
+	return nil
+}
+
+// JSONOption configures ScheduleFromJSON and WindowScheduleFromJSON.
+type JSONOption func(*jsonConfig)
+
+type jsonConfig struct {
+	strictLocation bool
+}
+
+func newJSONConfig(opts []JSONOption) jsonConfig {
+	cfg := jsonConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// check checks s as decoded, before it is corrected.
+func (c jsonConfig) check(s *Schedule) error {
+	if c.strictLocation {
+		if _, err := loadLocation(s.Location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StrictLocation makes decoding fail on unknown locations instead of
+// falling back to time.Local.
+func StrictLocation() JSONOption {
+	return func(c *jsonConfig) {
+		c.strictLocation = true
+	}
+}
+
+func ScheduleFromJSON(data string, opts ...JSONOption) (*Schedule, error) {
 	var s *Schedule
 	if err := json.Unmarshal([]byte(data), &s); err != nil {
 		return nil, err
 	}
+	if err := newJSONConfig(opts).check(s); err != nil {
+		return nil, err
+	}
 	s.once.Do(s.correct)
 	return s, nil
 }
@@ -91,9 +199,11 @@ func (s *Schedule) WithLoc(loc *time.Location) *Schedule {
 	return s
 }
 
+// WithLocString sets the location by name, falling back to time.Local
+// when it is unknown. Use WithLocStringE to catch typos.
 func (s *Schedule) WithLocString(loc string) *Schedule {
 	s.Location = loc
-	tz, err := time.LoadLocation(loc)
+	tz, err := loadLocation(loc)
 	if err != nil {
 		tz = time.Local
 	}
@@ -102,11 +212,51 @@ func (s *Schedule) WithLocString(loc string) *Schedule {
 	return s
 }
 
+// WithLocStringE is WithLocString reporting unknown locations instead of
+// falling back to time.Local. The schedule is left unchanged on error.
+func (s *Schedule) WithLocStringE(loc string) (*Schedule, error) {
+	tz, err := loadLocation(loc)
+	if err != nil {
+		return s, err
+	}
+	return s.WithLoc(tz), nil
+}
+
 func (s *Schedule) WithDuration(dur time.Duration) *Schedule {
 	s.Duration = dur
 	return s
 }
 
+func (s *Schedule) WithCalendar(calendars ...Calendar) *Schedule {
+	s.Calendars = calendars
+	return s
+}
+
+func (s *Schedule) WithShift(policy ShiftPolicy) *Schedule {
+	s.Shift = policy
+	return s
+}
+
+func (s *Schedule) WithDSTGap(policy DSTGap) *Schedule {
+	s.DSTGap = policy
+	return s
+}
+
+func (s *Schedule) WithDSTOverlap(policy DSTOverlap) *Schedule {
+	s.DSTOverlap = policy
+	return s
+}
+
+func (s *Schedule) WithDayMode(mode DayMode) *Schedule {
+	s.DayMode = mode
+	return s
+}
+
+// dayOr reports whether DayField and DayOfWeekField are ORed together.
+func (s *Schedule) dayOr() bool {
+	return s.DayMode == DayModeOr && len(s.DayField) > 0 && len(s.DayOfWeekField) > 0
+}
+
 func (s *Schedule) Year(units ...*Unit[int]) *Schedule {
 	s.YearField = units
 	return s
@@ -132,6 +282,55 @@ func (s *Schedule) DayOfWeek(units ...*Unit[time.Weekday]) *Schedule {
 	return s
 }
 
+func (s *Schedule) Feast(feasts ...Feast) *Schedule {
+	s.FeastField = feasts
+	return s
+}
+
+func (s *Schedule) LunarMonth(units ...*Unit[int]) *Schedule {
+	s.LunarMonthField = units
+	return s
+}
+
+func (s *Schedule) LunarDay(units ...*Unit[int]) *Schedule {
+	s.LunarDayField = units
+	return s
+}
+
+// WithLunarLeapMonth lets leap months match LunarMonthField as the month
+// they repeat.
+func (s *Schedule) WithLunarLeapMonth(leap bool) *Schedule {
+	s.LunarLeapMonth = leap
+	return s
+}
+
+// WithLunarZone sets the zone, in hours east of UTC, lunar dates are
+// computed in. It defaults to LunarVietnam.
+func (s *Schedule) WithLunarZone(zone int) *Schedule {
+	s.LunarZone = zone
+	return s
+}
+
+// BusinessDay matches the nth business day of the month, see BusinessDayOf.
+func (s *Schedule) BusinessDay(units ...*Unit[int]) *Schedule {
+	s.BusinessDayField = units
+	s.BusinessDayFromEnd = false
+	return s
+}
+
+// LastBusinessDay matches the nth business day counted back from the end
+// of the month, At(1) being the last one.
+func (s *Schedule) LastBusinessDay(units ...*Unit[int]) *Schedule {
+	s.BusinessDayField = units
+	s.BusinessDayFromEnd = true
+	return s
+}
+
+func (s *Schedule) BusinessDayOf(period Period) *Schedule {
+	s.BusinessDayPeriod = period
+	return s
+}
+
 func (s *Schedule) Hour(field ...*Unit[int]) *Schedule {
 	s.HourField = field
 	return s
@@ -147,11 +346,35 @@ func (s *Schedule) Second(field ...*Unit[int]) *Schedule {
 	return s
 }
 
+// Next returns the first occurrence at or after t, expressed in the
+// schedule's location.
 func (s *Schedule) Next(t time.Time) *time.Time {
 	s.once.Do(s.correct)
-	t = t.In(s.Loc)
-	now := T(t)
-	res := T(t)
+	if s.Jitter > 0 {
+		return s.nextJittered(t.In(s.Loc))
+	}
+	return s.nextRaw(t)
+}
+
+// nextRaw picks the first kind of schedule set, in order: interval, solar
+// event, calendars, then the fields and recurrence.
+func (s *Schedule) nextRaw(t time.Time) *time.Time {
+	if s.Interval > 0 {
+		return s.nextInterval(t.In(s.Loc))
+	}
+	if s.Solar != nil {
+		return s.nextSolar(t.In(s.Loc))
+	}
+	if len(s.Calendars) > 0 {
+		return s.nextShifted(t.In(s.Loc))
+	}
+	return s.next(t)
+}
+
+// nextWall works on wall clock fields only, leaving DST resolution to
+// next.
+func (s *Schedule) nextWall(now Time) *Time {
+	res := now
 	// check under time
 	uY, uM, uW, uD, uH, uMin := false, false, false, false, false, false
 year:
@@ -163,7 +386,7 @@ year:
 	if res.Year == -1 {
 		return nil
 	}
-	uY = res.Year > t.Year()
+	uY = res.Year > now.Year
 	res.Month = now.Month
 	if uY {
 		res.Month = time.January
@@ -174,11 +397,11 @@ month:
 		monthField = AnyMonth
 	}
 	res.Month = monthField.Next(res.Month)
-	if res.Month == -1 {
+	if res.Month == -1 || res.Month > time.December {
 		res.Year++
 		goto year
 	}
-	uM = uY || res.Month > t.Month()
+	uM = uY || res.Month > now.Month
 	res.Week = now.Week
 	if uM {
 		res.Week = 1
@@ -195,12 +418,52 @@ month:
 		firstDayOfMonth := time.Date(res.Year, res.Month, 1, 0, 0, 0, 0, s.Loc)
 		wd := firstDayOfMonth.Weekday()
 		for i := 1; i <= maxDayOfMonth; i++ {
-			if dayOfWeekField.Match(wd) {
+			if dayOfWeekField.Match(wd) || s.dayOr() && s.DayField.Match(i) {
 				dayPool = append(dayPool, i)
 			}
 			wd = (wd + 1) % 7
 		}
 	}
+	if s.hasFiscal() {
+		fiscalPool := s.fiscalPool(res.Year, res.Month)
+		if poolDayValidate {
+			fiscalPool = intersect(dayPool, fiscalPool)
+		}
+		poolDayValidate = true
+		dayPool = fiscalPool
+	}
+	if s.RecurEvery > 0 {
+		recurPool := s.recurPool(res.Year, res.Month)
+		if poolDayValidate {
+			recurPool = intersect(dayPool, recurPool)
+		}
+		poolDayValidate = true
+		dayPool = recurPool
+	}
+	if len(s.LunarMonthField) > 0 || len(s.LunarDayField) > 0 {
+		lunarPool := s.lunarPool(res.Year, res.Month)
+		if poolDayValidate {
+			lunarPool = intersect(dayPool, lunarPool)
+		}
+		poolDayValidate = true
+		dayPool = lunarPool
+	}
+	if len(s.FeastField) > 0 {
+		feastPool := s.feastPool(res.Year, res.Month)
+		if poolDayValidate {
+			feastPool = intersect(dayPool, feastPool)
+		}
+		poolDayValidate = true
+		dayPool = feastPool
+	}
+	if len(s.BusinessDayField) > 0 {
+		businessDayPool := s.businessDayPool(res.Year, res.Month)
+		if poolDayValidate {
+			businessDayPool = intersect(dayPool, businessDayPool)
+		}
+		poolDayValidate = true
+		dayPool = businessDayPool
+	}
 	res.Day = now.Day
 	if uM {
 		res.Day = 1
@@ -212,7 +475,7 @@ week:
 	if len(weekField) != 0 {
 		wDayPoolValidate = true
 		res.Week = weekField.Next(res.Week)
-		if res.Week == -1 {
+		if res.Week == -1 || res.Week > 5 {
 			res.Month++
 			goto month
 		}
@@ -228,7 +491,7 @@ week:
 	uW = uM || (res.Week != -1 && res.Week > res.Week)
 day:
 	dayField := s.DayField
-	if len(dayField) == 0 {
+	if len(dayField) == 0 || s.dayOr() {
 		dayField = AnyDay
 	}
 	if wDayPoolValidate {
@@ -238,7 +501,7 @@ day:
 	} else {
 		res.Day = dayField.Next(res.Day)
 	}
-	if res.Day == -1 {
+	if res.Day == -1 || res.Day > maxDayOfMonth {
 		if len(weekField) == 0 {
 			res.Month++
 			goto month
@@ -246,7 +509,7 @@ day:
 		res.Week++
 		goto week
 	}
-	uD = uW || res.Day > t.Day()
+	uD = uW || res.Day > now.Day
 	res.Hour = now.Hour
 	if uD {
 		res.Hour = 0
@@ -257,11 +520,11 @@ hour:
 		hourField = AnyHour
 	}
 	res.Hour = hourField.Next(res.Hour)
-	if res.Hour == -1 {
+	if res.Hour == -1 || res.Hour > 23 {
 		res.Day++
 		goto day
 	}
-	uH = uD || res.Hour > t.Hour()
+	uH = uD || res.Hour > now.Hour
 	res.Minute = now.Minute
 	if uH {
 		res.Minute = 0
@@ -272,11 +535,11 @@ minute:
 		minField = AnyMinute
 	}
 	res.Minute = minField.Next(res.Minute)
-	if res.Minute == -1 {
+	if res.Minute == -1 || res.Minute > 59 {
 		res.Hour++
 		goto hour
 	}
-	uMin = uH || res.Minute > t.Minute()
+	uMin = uH || res.Minute > now.Minute
 	res.Second = now.Second
 	if uMin {
 		res.Second = 0
@@ -287,18 +550,40 @@ minute:
 		secField = AnySecond
 	}
 	res.Second = secField.Next(res.Second)
-	if res.Second == -1 {
+	if res.Second == -1 || res.Second > 59 {
 		res.Minute++
 		goto minute
 	}
-	return ptr(res.ToTime())
+	return &res
 }
 
+// Previous returns the last occurrence at or before t, expressed in the
+// schedule's location.
 func (s *Schedule) Previous(t time.Time) *time.Time {
 	s.once.Do(s.correct)
-	t = t.In(s.Loc)
-	now := T(t)
-	res := T(t)
+	if s.Jitter > 0 {
+		return s.previousJittered(t.In(s.Loc))
+	}
+	return s.previousRaw(t)
+}
+
+func (s *Schedule) previousRaw(t time.Time) *time.Time {
+	if s.Interval > 0 {
+		return s.previousInterval(t.In(s.Loc))
+	}
+	if s.Solar != nil {
+		return s.previousSolar(t.In(s.Loc))
+	}
+	if len(s.Calendars) > 0 {
+		return s.previousShifted(t.In(s.Loc))
+	}
+	return s.previous(t)
+}
+
+// previousWall works on wall clock fields only, leaving DST resolution to
+// previous.
+func (s *Schedule) previousWall(now Time) *Time {
+	res := now
 	// check over time
 	oY, oM, oW, oD, oH, oMin := false, false, false, false, false, false
 year:
@@ -310,7 +595,7 @@ year:
 	if res.Year == -1 {
 		return nil
 	}
-	oY = res.Year < t.Year()
+	oY = res.Year < now.Year
 	res.Month = now.Month
 	if oY {
 		res.Month = time.December
@@ -326,7 +611,7 @@ month:
 		res.Year--
 		goto year
 	}
-	oM = oY || res.Month < t.Month()
+	oM = oY || res.Month < now.Month
 	res.Week = now.Week
 	if oM {
 		res.Week = 5
@@ -343,12 +628,52 @@ month:
 		firstDayOfMonth := time.Date(res.Year, res.Month, 1, 0, 0, 0, 0, s.Loc)
 		wd := firstDayOfMonth.Weekday()
 		for i := 1; i <= maxDayOfMonth; i++ {
-			if dayOfWeekField.Match(wd) {
+			if dayOfWeekField.Match(wd) || s.dayOr() && s.DayField.Match(i) {
 				dayPool = append(dayPool, i)
 			}
 			wd = (wd + 1) % 7
 		}
 	}
+	if s.hasFiscal() {
+		fiscalPool := s.fiscalPool(res.Year, res.Month)
+		if poolDayValidate {
+			fiscalPool = intersect(dayPool, fiscalPool)
+		}
+		poolDayValidate = true
+		dayPool = fiscalPool
+	}
+	if s.RecurEvery > 0 {
+		recurPool := s.recurPool(res.Year, res.Month)
+		if poolDayValidate {
+			recurPool = intersect(dayPool, recurPool)
+		}
+		poolDayValidate = true
+		dayPool = recurPool
+	}
+	if len(s.LunarMonthField) > 0 || len(s.LunarDayField) > 0 {
+		lunarPool := s.lunarPool(res.Year, res.Month)
+		if poolDayValidate {
+			lunarPool = intersect(dayPool, lunarPool)
+		}
+		poolDayValidate = true
+		dayPool = lunarPool
+	}
+	if len(s.FeastField) > 0 {
+		feastPool := s.feastPool(res.Year, res.Month)
+		if poolDayValidate {
+			feastPool = intersect(dayPool, feastPool)
+		}
+		poolDayValidate = true
+		dayPool = feastPool
+	}
+	if len(s.BusinessDayField) > 0 {
+		businessDayPool := s.businessDayPool(res.Year, res.Month)
+		if poolDayValidate {
+			businessDayPool = intersect(dayPool, businessDayPool)
+		}
+		poolDayValidate = true
+		dayPool = businessDayPool
+	}
 	res.Day = now.Day
 	if oM {
 		res.Day = maxDayOfMonth
@@ -385,7 +710,7 @@ week:
 
 day:
 	dayField := s.DayField
-	if len(dayField) == 0 {
+	if len(dayField) == 0 || s.dayOr() {
 		dayField = AnyDay
 	}
 	if wDayPoolValidate {
@@ -403,7 +728,7 @@ day:
 		res.Week--
 		goto week
 	}
-	oD = oW || res.Day < t.Day()
+	oD = oW || res.Day < now.Day
 	res.Hour = now.Hour
 	if oD {
 		res.Hour = 23
@@ -419,7 +744,7 @@ hour:
 		res.Day--
 		goto day
 	}
-	oH = oD || res.Hour < t.Hour()
+	oH = oD || res.Hour < now.Hour
 	res.Minute = now.Minute
 	if oH {
 		res.Minute = 59
@@ -434,7 +759,7 @@ minute:
 		res.Hour--
 		goto hour
 	}
-	oMin = oH || res.Minute < t.Minute()
+	oMin = oH || res.Minute < now.Minute
 	res.Second = now.Second
 	if oMin {
 		res.Second = 59
@@ -449,9 +774,26 @@ minute:
 		res.Minute--
 		goto minute
 	}
-	return ptr(res.ToTime())
+	return &res
+}
+
+// NextAfter returns the first occurrence strictly after t.
+func (s *Schedule) NextAfter(t time.Time) *time.Time {
+	return s.Next(t.Truncate(time.Second).Add(time.Second))
+}
+
+// PreviousBefore returns the last occurrence strictly before t.
+func (s *Schedule) PreviousBefore(t time.Time) *time.Time {
+	prev := t.Truncate(time.Second)
+	if prev.Equal(t) {
+		prev = prev.Add(-time.Second)
+	}
+	return s.Previous(prev)
 }
 
+// InProgress reports whether the window of any occurrence at or before t,
+// lasting Duration, still covers t. Occurrences closer together than
+// Duration overlap, and t is in progress while any of them is.
 func (s *Schedule) InProgress(t time.Time) bool {
 	s.once.Do(s.correct)
 	if s.StartTime != nil && s.StartTime.After(t) {
@@ -460,18 +802,47 @@ func (s *Schedule) InProgress(t time.Time) bool {
 	if s.EndTime != nil && s.EndTime.Before(t) {
 		return false
 	}
-	prev := s.Previous(t)
-	if prev == nil {
-		return false
+	return len(s.covering(t, 1)) > 0
+}
+
+// covering walks back from t over the occurrences starting after
+// t-Duration, those whose window covers t, and returns up to limit of them,
+// latest first. A limit of 0 returns all.
+func (s *Schedule) covering(t time.Time, limit int) []time.Time {
+	res := make([]time.Time, 0)
+	since := t.Add(-s.Duration)
+	for occ := s.Previous(t); occ != nil && occ.After(since); occ = s.PreviousBefore(*occ) {
+		res = append(res, *occ)
+		if len(res) == limit {
+			break
+		}
 	}
-	return prev.Add(s.Duration).After(t)
+	return res
 }
 
 func (s *Schedule) String() string {
 	s.once.Do(s.correct)
 	b := strings.Builder{}
 	pre := false
+	if s.Interval > 0 {
+		pre = true
+		b.WriteString(s.intervalString())
+	}
+	if s.Solar != nil {
+		pre = true
+		b.WriteString(s.solarString())
+	}
+	if s.RecurEvery > 0 {
+		if pre {
+			b.WriteString(", ")
+		}
+		pre = true
+		b.WriteString(s.recurString())
+	}
 	if s.YearField != nil {
+		if pre {
+			b.WriteString(", ")
+		}
 		pre = true
 		b.WriteString(s.YearField.String("year"))
 	}
@@ -497,12 +868,42 @@ func (s *Schedule) String() string {
 		b.WriteString(s.DayField.String("day"))
 	}
 	if s.DayOfWeekField != nil {
-		if pre {
+		if pre && s.dayOr() {
+			b.WriteString(" or ")
+		} else if pre {
 			b.WriteString(", ")
 		}
 		pre = true
 		b.WriteString(s.DayOfWeekField.String(""))
 	}
+	if s.LunarMonthField != nil || s.LunarDayField != nil {
+		if pre {
+			b.WriteString(", ")
+		}
+		pre = true
+		b.WriteString(s.lunarString())
+	}
+	if s.hasFiscal() {
+		if pre {
+			b.WriteString(", ")
+		}
+		pre = true
+		b.WriteString(s.fiscalString())
+	}
+	if s.FeastField != nil {
+		if pre {
+			b.WriteString(", ")
+		}
+		pre = true
+		b.WriteString(s.feastString())
+	}
+	if s.BusinessDayField != nil {
+		if pre {
+			b.WriteString(", ")
+		}
+		pre = true
+		b.WriteString(s.businessDayString())
+	}
 	if s.HourField != nil {
 		if pre {
 			b.WriteString(", ")
@@ -524,6 +925,10 @@ func (s *Schedule) String() string {
 		pre = true
 		b.WriteString(s.SecondField.String("second"))
 	}
+	if len(s.Calendars) > 0 {
+		b.WriteString(", ")
+		b.WriteString(s.Shift.String())
+	}
 	if s.StartTime != nil {
 		b.WriteString(", start from ")
 		b.WriteString(s.StartTime.In(s.Loc).Format(time.RFC850))
@@ -537,6 +942,11 @@ func (s *Schedule) String() string {
 		b.WriteString(s.Duration.String())
 		b.WriteString(" duration")
 	}
+	if s.Jitter != 0 {
+		b.WriteString(" with up to ")
+		b.WriteString(s.Jitter.String())
+		b.WriteString(" jitter")
+	}
 
 	return b.String()
 }
@@ -84,6 +84,35 @@ func TestSchedule_WithLocStringDefault(t *testing.T) {
 	assert.Equal(t, time.Local.String(), s.Loc.String())
 }
 
+func TestSchedule_WithLocStringE(t *testing.T) {
+	s, err := Scheduler().WithLocStringE("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Ho_Chi_Minh", s.Location)
+	s, err = s.WithLocStringE("Asia/HoChiMinh")
+	assert.Error(t, err)
+	assert.Equal(t, "Asia/Ho_Chi_Minh", s.Location)
+	assert.Equal(t, "Asia/Ho_Chi_Minh", s.Loc.String())
+}
+
+func TestSchedule_WithLocStringOffset(t *testing.T) {
+	s := Scheduler().WithLocString("UTC+07:00")
+	assert.Equal(t, "UTC+07:00", s.Location)
+	_, offset := time.Date(2024, 1, 1, 0, 0, 0, 0, s.Loc).Zone()
+	assert.Equal(t, 7*60*60, offset)
+	assert.Equal(t, ptr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)), ptr(s.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).UTC()))
+}
+
+func TestScheduleFromJSON_StrictLocation(t *testing.T) {
+	_, err := ScheduleFromJSON(`{"location":"Asia/HoChiMinh"}`, StrictLocation())
+	assert.Error(t, err)
+	s, err := ScheduleFromJSON(`{"location":"Asia/HoChiMinh"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Local.String(), s.Location)
+	s, err = ScheduleFromJSON(`{"location":"GMT-05:30"}`, StrictLocation())
+	assert.NoError(t, err)
+	assert.Equal(t, "UTC-05:30", s.Location)
+}
+
 func TestSchedule_WithDuration(t *testing.T) {
 	dur := time.Hour
 	s := Scheduler().WithDuration(dur)
@@ -160,3 +189,117 @@ func TestSchedule(t *testing.T) {
 	assert.Equal(t, ptr(time.Date(2023, 10, 31, 12, 30, 0, 0, time.Local)), s.Previous(time.Date(2023, 11, 1, 0, 0, 0, 0, time.Local)))
 	assert.Equal(t, ptr(time.Date(2023, 11, 1, 12, 30, 0, 0, time.Local)), s.Previous(time.Date(2023, 11, 2, 0, 0, 0, 0, time.Local)))
 }
+
+func TestSchedule_NextAfter(t *testing.T) {
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	// any
+	s := Scheduler()
+	assert.Equal(t, ptr(at.Add(time.Second)), s.NextAfter(at))
+	assert.Equal(t, ptr(at.Add(time.Second)), s.NextAfter(at.Add(500*time.Millisecond)))
+	// second
+	s = Scheduler().Second(At(0))
+	assert.Equal(t, ptr(at.Add(time.Minute)), s.NextAfter(at))
+	// minute
+	s = Scheduler().Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.Add(time.Hour)), s.NextAfter(at))
+	// hour
+	s = Scheduler().Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 1)), s.NextAfter(at))
+	// day
+	s = Scheduler().Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, 1, 0)), s.NextAfter(at))
+	// day of week
+	s = Scheduler().DayOfWeek(At(time.Sunday)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 7)), s.NextAfter(at))
+	// week
+	s = Scheduler().Week(At(1)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, 1, 0)), s.NextAfter(at))
+	// month
+	s = Scheduler().Month(At(time.January)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(1, 0, 0)), s.NextAfter(at))
+	// year
+	s = Scheduler().Year(At(2023)).Month(At(time.January)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Nil(t, s.NextAfter(at))
+}
+
+func TestSchedule_PreviousBefore(t *testing.T) {
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.Local)
+	// any
+	s := Scheduler()
+	assert.Equal(t, ptr(at.Add(-time.Second)), s.PreviousBefore(at))
+	assert.Equal(t, ptr(at), s.PreviousBefore(at.Add(500*time.Millisecond)))
+	// second
+	s = Scheduler().Second(At(0))
+	assert.Equal(t, ptr(at.Add(-time.Minute)), s.PreviousBefore(at))
+	// minute
+	s = Scheduler().Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.Add(-time.Hour)), s.PreviousBefore(at))
+	// hour
+	s = Scheduler().Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, 0, -1)), s.PreviousBefore(at))
+	// day
+	s = Scheduler().Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, -1, 0)), s.PreviousBefore(at))
+	// day of week
+	s = Scheduler().DayOfWeek(At(time.Sunday)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, 0, -7)), s.PreviousBefore(at))
+	// week
+	s = Scheduler().Week(At(1)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(0, -1, 0)), s.PreviousBefore(at))
+	// month
+	s = Scheduler().Month(At(time.January)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Equal(t, ptr(at.AddDate(-1, 0, 0)), s.PreviousBefore(at))
+	// year
+	s = Scheduler().Year(At(2023)).Month(At(time.January)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	assert.Nil(t, s.PreviousBefore(at))
+}
+
+func TestSchedule_DayModeOr(t *testing.T) {
+	// 1st, 15th and every Monday at 00:00
+	s := Scheduler().WithDayMode(DayModeOr).
+		Day(At(1), At(15)).DayOfWeek(At(time.Monday)).
+		Hour(At(0)).Minute(At(0)).Second(At(0))
+	// 2023-10-01 is a Sunday
+	at := time.Date(2023, 10, 1, 0, 0, 0, 0, time.Local)
+	assert.Equal(t, ptr(at.AddDate(0, 0, 1)), s.NextAfter(at))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 8)), s.NextAfter(at.AddDate(0, 0, 1)))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 14)), s.NextAfter(at.AddDate(0, 0, 8)))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 15)), s.NextAfter(at.AddDate(0, 0, 14)))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 14)), s.PreviousBefore(at.AddDate(0, 0, 15)))
+	assert.Equal(t, ptr(at.AddDate(0, 0, 8)), s.PreviousBefore(at.AddDate(0, 0, 14)))
+	// 2023-09-25 is the last Monday of September
+	assert.Equal(t, ptr(time.Date(2023, 9, 25, 0, 0, 0, 0, time.Local)), s.PreviousBefore(at))
+
+	s.WithDuration(time.Hour)
+	assert.True(t, s.InProgress(at.AddDate(0, 0, 8).Add(time.Minute)))
+	assert.False(t, s.InProgress(at.AddDate(0, 0, 9).Add(time.Minute)))
+	assert.Equal(t, "at 1st day and at 15th day or at Monday, at 0th hour, at 0th minute, at 0th second with 1h0m0s duration", s.String())
+
+	// and is still the default
+	s.WithDayMode(DayModeAnd)
+	assert.Equal(t, ptr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)), s.NextAfter(at))
+	assert.Equal(t, "at 1st day and at 15th day, at Monday, at 0th hour, at 0th minute, at 0th second with 1h0m0s duration", s.String())
+}
+
+func TestSchedule_InProgressOverlapping(t *testing.T) {
+	// hourly with a 3 hour duration, three windows overlap at any time
+	s := Scheduler().WithLoc(time.UTC).Minute(At(0)).Second(At(0)).WithDuration(3 * time.Hour)
+	assert.True(t, s.InProgress(time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)))
+
+	// every 6 hours for 8 hours, open from midnight to 02:00 the next day
+	s = Scheduler().WithLoc(time.UTC).Hour(At(0), At(6), At(12), At(18)).Minute(At(0)).Second(At(0)).
+		WithDuration(8 * time.Hour)
+	assert.True(t, s.InProgress(time.Date(2024, 1, 1, 13, 59, 59, 0, time.UTC)))
+	assert.True(t, s.InProgress(time.Date(2024, 1, 2, 1, 59, 59, 0, time.UTC)))
+
+	// weekly on Monday for 10 days, covering the gap before the next Monday
+	s = Scheduler().WithLoc(time.UTC).DayOfWeek(At(time.Monday)).Hour(At(0)).Minute(At(0)).Second(At(0)).
+		WithDuration(10 * 24 * time.Hour)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.StartAt(&start)
+	assert.False(t, s.InProgress(start.Add(-time.Second)))
+	assert.True(t, s.InProgress(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)))
+	end := time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC)
+	s.EndAt(&end)
+	assert.False(t, s.InProgress(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)))
+}
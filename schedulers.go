@@ -1,6 +1,9 @@
 package timewalk
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 type Schedulers []*Schedule
 
@@ -16,3 +19,121 @@ func (s Schedulers) InProgress(t time.Time) bool {
 	}
 	return false
 }
+
+// maxTransitionSpan bounds how far overlapping windows are chained together
+// looking for their end.
+const maxTransitionSpan = 366 * 24 * time.Hour
+
+// NextTransition returns the first instant after t the enabled schedules go
+// in or out of progress at, or nil when they stay as they are. Overlapping
+// windows chain, only closing once none of them is in progress. Chains
+// going on for more than a year are taken as never closing.
+func (s Schedulers) NextTransition(t time.Time) *Transition {
+	if s.InProgress(t) {
+		res := &Transition{Time: t, Open: false}
+		for {
+			end := res.Time
+			for _, v := range s {
+				if !v.Enable {
+					continue
+				}
+				if w := v.latestWindowAt(end); w != nil && w.End.After(res.Time) {
+					res.Time, res.Schedule = w.End, v
+				}
+			}
+			if !res.Time.After(end) || !s.InProgress(res.Time) {
+				break
+			}
+			if res.Time.Sub(t) > maxTransitionSpan {
+				return nil
+			}
+		}
+		return res
+	}
+	var res *Transition
+	for _, v := range s {
+		if !v.Enable || v.Duration <= 0 {
+			continue
+		}
+		if open := v.nextOpen(t); open != nil && (res == nil || open.Before(res.Time)) {
+			res = &Transition{Time: *open, Open: true, Schedule: v}
+		}
+	}
+	return res
+}
+
+// windowsAt returns the windows of the enabled schedules in progress at t.
+func (s Schedulers) windowsAt(t time.Time) []Window {
+	res := make([]Window, 0)
+	for _, v := range s {
+		if v.Enable {
+			res = append(res, v.WindowsAt(t)...)
+		}
+	}
+	return res
+}
+
+// Next returns the earliest occurrence at or after t among the enabled
+// schedules, expressed in loc.
+func (s Schedulers) Next(t time.Time, loc *time.Location) *Occurrence {
+	var res *Occurrence
+	for _, v := range s {
+		if !v.Enable {
+			continue
+		}
+		next := v.Next(t)
+		if next == nil || res != nil && !next.Before(res.Time) {
+			continue
+		}
+		res = &Occurrence{Schedule: v, Time: *next}
+	}
+	if res == nil {
+		return nil
+	}
+	return ptr(res.In(loc))
+}
+
+// next returns the earliest occurrence at or after t, expressed in the
+// location of its schedule.
+func (s Schedulers) next(t time.Time) *time.Time {
+	if occ := s.Next(t, time.UTC); occ != nil {
+		return ptr(occ.Zoned())
+	}
+	return nil
+}
+
+// Between returns the occurrences of the enabled schedules from from up to
+// to, in chronological order and expressed in loc.
+func (s Schedulers) Between(from, to time.Time, loc *time.Location) []Occurrence {
+	res := make([]Occurrence, 0)
+	for _, v := range s {
+		if !v.Enable {
+			continue
+		}
+		for _, occ := range v.Between(from, to) {
+			res = append(res, Occurrence{Schedule: v, Time: occ.In(loc)})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Time.Before(res[j].Time)
+	})
+	return res
+}
+
+// Windows returns the windows of the enabled schedules in progress at some
+// point from from up to to, ordered by start and expressed in loc.
+func (s Schedulers) Windows(from, to time.Time, loc *time.Location) []Window {
+	res := make([]Window, 0)
+	for _, v := range s {
+		if !v.Enable {
+			continue
+		}
+		for _, w := range v.Windows(from, to) {
+			res = append(res, w.In(loc))
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Start.Before(res[j].Start)
+	})
+	return res
+}
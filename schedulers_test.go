@@ -0,0 +1,101 @@
+package timewalk
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSchedulers_Next(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	a := Scheduler().WithLoc(hcm).Hour(At(9)).Minute(At(0)).Second(At(0))
+	b := Scheduler().WithLoc(ny).Hour(At(9)).Minute(At(0)).Second(At(0))
+	a.Enable, b.Enable = true, true
+	list := Schedulers{a, b}
+
+	// 09:00 in Ho Chi Minh city comes first
+	next := list.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+	assert.Equal(t, a, next.Schedule)
+	assert.Equal(t, time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), next.Time)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, hcm), next.Zoned())
+	next = list.Next(time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), time.UTC)
+	assert.Equal(t, b, next.Schedule)
+	assert.Equal(t, time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), next.Time)
+
+	a.Enable, b.Enable = false, false
+	assert.Nil(t, list.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC))
+}
+
+func TestSchedulers_Between(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+	ny, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+	a := Scheduler().WithLoc(hcm).Hour(At(9)).Minute(At(0)).Second(At(0))
+	b := Scheduler().WithLoc(ny).Hour(At(9)).Minute(At(0)).Second(At(0))
+	a.Enable, b.Enable = true, true
+	list := Schedulers{b, a}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	occ := list.Between(from, from.AddDate(0, 0, 1), hcm)
+	assert.Len(t, occ, 2)
+	assert.Equal(t, a, occ[0].Schedule)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, hcm), occ[0].Time)
+	assert.Equal(t, b, occ[1].Schedule)
+	assert.Equal(t, time.Date(2024, 1, 1, 21, 0, 0, 0, hcm), occ[1].Time)
+}
+
+func TestSchedulers_Windows(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+	a := Scheduler().WithLoc(hcm).Hour(At(9)).Minute(At(0)).Second(At(0)).WithDuration(time.Hour)
+	b := Scheduler().WithLoc(time.UTC).Hour(At(2)).Minute(At(30)).Second(At(0)).WithDuration(time.Hour)
+	a.Enable, b.Enable = true, true
+	list := Schedulers{b, a}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	ws := list.Windows(from, from.AddDate(0, 0, 1), time.UTC)
+	assert.Len(t, ws, 2)
+	assert.Equal(t, a, ws[0].Schedule)
+	assert.Equal(t, time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC), ws[0].Start)
+	assert.Equal(t, b, ws[1].Schedule)
+	assert.True(t, ws[0].Overlaps(ws[1]))
+}
+
+func TestSchedulers_NextTransition(t *testing.T) {
+	// 09:00 to 11:00 and 10:00 to 12:00 overlap
+	a := Scheduler().WithLoc(time.UTC).Hour(At(9)).Minute(At(0)).Second(At(0)).WithDuration(2 * time.Hour)
+	b := Scheduler().WithLoc(time.UTC).Hour(At(10)).Minute(At(0)).Second(At(0)).WithDuration(2 * time.Hour)
+	c := Scheduler().WithLoc(time.UTC).Hour(At(15)).Minute(At(0)).Second(At(0))
+	a.Enable, b.Enable, c.Enable = true, true, true
+	list := Schedulers{a, b, c}
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, &Transition{Time: day.Add(9 * time.Hour), Open: true, Schedule: a}, list.NextTransition(day))
+	assert.Equal(t, &Transition{Time: day.Add(12 * time.Hour), Open: false, Schedule: b}, list.NextTransition(day.Add(9*time.Hour)))
+	// windows without duration never open
+	assert.Equal(t, &Transition{Time: day.Add(33 * time.Hour), Open: true, Schedule: a}, list.NextTransition(day.Add(12*time.Hour)))
+
+	// opening when the schedule starts
+	start := day.Add(30 * time.Minute)
+	d := Scheduler().WithLoc(time.UTC).Minute(At(0)).Second(At(0)).WithDuration(time.Hour)
+	d.Enable = true
+	d.StartAt(&start)
+	assert.Equal(t, &Transition{Time: start, Open: true, Schedule: d}, Schedulers{d}.NextTransition(day.Add(-time.Hour)))
+
+	// always in progress
+	d.StartAt(nil)
+	d.WithDuration(2 * time.Hour)
+	assert.Nil(t, Schedulers{d}.NextTransition(day))
+	assert.Nil(t, Schedulers{c}.NextTransition(day))
+	e := Scheduler().WithLoc(time.UTC).Second(At(0)).WithDuration(2 * time.Hour)
+	e.Enable = true
+	assert.Nil(t, Schedulers{e}.NextTransition(day))
+
+	// chained for a while
+	end := day.Add(200 * 24 * time.Hour)
+	e.EndAt(&end)
+	assert.Equal(t, &Transition{Time: end, Open: false, Schedule: e}, Schedulers{e}.NextTransition(day))
+}
@@ -0,0 +1,185 @@
+package timewalk
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// maxSolarDays bounds the search for a solar event, polar days and nights
+// lasting up to half a year.
+const maxSolarDays = 732
+
+type SolarEventType int
+
+const (
+	Sunrise SolarEventType = iota
+	Sunset
+	SolarNoon
+	CivilDawn
+	CivilDusk
+	NauticalDawn
+	NauticalDusk
+	AstronomicalDawn
+	AstronomicalDusk
+)
+
+var solarEventNames = map[SolarEventType]string{
+	Sunrise:          "sunrise",
+	Sunset:           "sunset",
+	SolarNoon:        "solar noon",
+	CivilDawn:        "civil dawn",
+	CivilDusk:        "civil dusk",
+	NauticalDawn:     "nautical dawn",
+	NauticalDusk:     "nautical dusk",
+	AstronomicalDawn: "astronomical dawn",
+	AstronomicalDusk: "astronomical dusk",
+}
+
+func (e SolarEventType) String() string {
+	return solarEventNames[e]
+}
+
+// zenith returns the zenith angle of the sun, in degrees, at the event.
+func (e SolarEventType) zenith() float64 {
+	switch e {
+	case CivilDawn, CivilDusk:
+		return 96
+	case NauticalDawn, NauticalDusk:
+		return 102
+	case AstronomicalDawn, AstronomicalDusk:
+		return 108
+	default:
+		return 90.833
+	}
+}
+
+func (e SolarEventType) rising() bool {
+	return e == Sunrise || e == CivilDawn || e == NauticalDawn || e == AstronomicalDawn
+}
+
+// SolarEvent is an event of the sun seen from a place, shifted by Offset.
+type SolarEvent struct {
+	Event     SolarEventType `json:"event"`
+	Latitude  float64        `json:"latitude"`
+	Longitude float64        `json:"longitude"`
+	Offset    time.Duration  `json:"offset"`
+}
+
+// SolarTime returns when event happens on the UTC date of date at latitude
+// and longitude, in degrees north and east, or nil when the sun does not
+// reach the event's altitude that day. It follows the NOAA general solar
+// position equations, accurate to a minute or two.
+func SolarTime(date time.Time, latitude, longitude float64, event SolarEventType) *time.Time {
+	date = date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	minutes := 720.0
+	for i := 0; i < 2; i++ {
+		eqTime, decl := solarPosition(day, minutes)
+		ha := 0.0
+		if event != SolarNoon {
+			lat := latitude * math.Pi / 180
+			cos := math.Cos(event.zenith()*math.Pi/180)/(math.Cos(lat)*math.Cos(decl)) - math.Tan(lat)*math.Tan(decl)
+			if cos < -1 || cos > 1 {
+				return nil
+			}
+			ha = math.Acos(cos) * 180 / math.Pi
+			if event.rising() {
+				ha = -ha
+			}
+		}
+		minutes = 720 - 4*(longitude-ha) - eqTime
+	}
+	return ptr(day.Add(time.Duration(minutes * float64(time.Minute))).Truncate(time.Second))
+}
+
+// solarPosition returns the equation of time, in minutes, and the solar
+// declination, in radians, at minutes past midnight UTC of day.
+func solarPosition(day time.Time, minutes float64) (float64, float64) {
+	daysInYear := 365.0
+	if maxDay(day.Year(), time.February) == 29 {
+		daysInYear = 366
+	}
+	g := 2 * math.Pi / daysInYear * (float64(day.YearDay()-1) + (minutes/60-12)/24)
+	eqTime := 229.18 * (0.000075 + 0.001868*math.Cos(g) - 0.032077*math.Sin(g) -
+		0.014615*math.Cos(2*g) - 0.040849*math.Sin(2*g))
+	decl := 0.006918 - 0.399912*math.Cos(g) + 0.070257*math.Sin(g) -
+		0.006758*math.Cos(2*g) + 0.000907*math.Sin(2*g) -
+		0.002697*math.Cos(3*g) + 0.00148*math.Sin(3*g)
+	return eqTime, decl
+}
+
+// SolarScheduler returns a schedule occurring at event, shifted by offset,
+// every day at latitude and longitude. Year, month, day and day of week
+// fields restrict the days it occurs on, the time of day fields are
+// ignored.
+func SolarScheduler(event SolarEventType, latitude, longitude float64, offset time.Duration) *Schedule {
+	return Scheduler().WithSolar(event, latitude, longitude, offset)
+}
+
+func (s *Schedule) WithSolar(event SolarEventType, latitude, longitude float64, offset time.Duration) *Schedule {
+	s.Solar = &SolarEvent{
+		Event:     event,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Offset:    offset,
+	}
+	return s
+}
+
+// solarAt returns the occurrence of the UTC date of day, if any.
+func (s *Schedule) solarAt(day time.Time) *time.Time {
+	at := SolarTime(day, s.Solar.Latitude, s.Solar.Longitude, s.Solar.Event)
+	if at == nil {
+		return nil
+	}
+	res := at.Add(s.Solar.Offset).In(s.Loc)
+	local := T(res)
+	if len(s.YearField) > 0 && !s.YearField.Match(local.Year) ||
+		len(s.MonthField) > 0 && !s.MonthField.Match(local.Month) ||
+		len(s.DayField) > 0 && !s.DayField.Match(local.Day) ||
+		len(s.DayOfWeekField) > 0 && !s.DayOfWeekField.Match(local.DayOfWeek) {
+		return nil
+	}
+	return &res
+}
+
+func (s *Schedule) nextSolar(t time.Time) *time.Time {
+	day := t.UTC().Add(-s.Solar.Offset).AddDate(0, 0, -1)
+	for i := 0; i < maxSolarDays; i++ {
+		if occ := s.solarAt(day); occ != nil && !occ.Before(t.Truncate(time.Second)) {
+			return occ
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return nil
+}
+
+func (s *Schedule) previousSolar(t time.Time) *time.Time {
+	day := t.UTC().Add(-s.Solar.Offset).AddDate(0, 0, 1)
+	for i := 0; i < maxSolarDays; i++ {
+		if occ := s.solarAt(day); occ != nil && !occ.After(t) {
+			return occ
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+	return nil
+}
+
+func (s *Schedule) solarString() string {
+	b := strings.Builder{}
+	switch {
+	case s.Solar.Offset > 0:
+		b.WriteString(s.Solar.Offset.String())
+		b.WriteString(" after ")
+	case s.Solar.Offset < 0:
+		b.WriteString((-s.Solar.Offset).String())
+		b.WriteString(" before ")
+	default:
+		b.WriteString("at ")
+	}
+	b.WriteString(s.Solar.Event.String())
+	b.WriteString(fmt.Sprintf(" at %.4f, %.4f", s.Solar.Latitude, s.Solar.Longitude))
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func assertNear(t *testing.T, expected time.Time, actual *time.Time) {
+	assert.NotNil(t, actual)
+	if actual != nil {
+		assert.InDelta(t, 0, actual.Sub(expected).Minutes(), 2, actual.String())
+	}
+}
+
+func TestSolarTime(t *testing.T) {
+	// London on the summer solstice
+	day := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	assertNear(t, time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC), SolarTime(day, 51.5074, -0.1278, Sunrise))
+	assertNear(t, time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC), SolarTime(day, 51.5074, -0.1278, Sunset))
+	assertNear(t, time.Date(2024, 6, 21, 2, 55, 0, 0, time.UTC), SolarTime(day, 51.5074, -0.1278, CivilDawn))
+	assertNear(t, time.Date(2024, 6, 21, 12, 2, 0, 0, time.UTC), SolarTime(day, 51.5074, -0.1278, SolarNoon))
+	// Ho Chi Minh City
+	ict := time.FixedZone("ICT", 7*60*60)
+	day = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assertNear(t, time.Date(2024, 1, 1, 6, 11, 0, 0, ict), SolarTime(day, 10.7769, 106.7009, Sunrise))
+	assertNear(t, time.Date(2024, 1, 1, 17, 40, 0, 0, ict), SolarTime(day, 10.7769, 106.7009, Sunset))
+	// polar night in Tromso still has a civil dawn
+	day = time.Date(2024, 12, 21, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, SolarTime(day, 69.6492, 18.9553, Sunrise))
+	assert.NotNil(t, SolarTime(day, 69.6492, 18.9553, CivilDawn))
+}
+
+func TestSchedule_Solar(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	assert.NoError(t, err)
+	// 30 minutes before sunset
+	s := SolarScheduler(Sunset, 51.5074, -0.1278, -30*time.Minute).WithLoc(london).WithDuration(time.Hour)
+	from := time.Date(2024, 6, 21, 12, 0, 0, 0, london)
+	next := s.Next(from)
+	assertNear(t, time.Date(2024, 6, 21, 20, 51, 0, 0, london), next)
+	assert.Equal(t, london, next.Location())
+	assertNear(t, time.Date(2024, 6, 22, 20, 51, 0, 0, london), s.NextAfter(*next))
+	assert.Equal(t, next, s.Previous(next.Add(time.Minute)))
+	assertNear(t, time.Date(2024, 6, 20, 20, 51, 0, 0, london), s.PreviousBefore(*next))
+	assert.True(t, s.InProgress(next.Add(30*time.Minute)))
+	assert.False(t, s.InProgress(next.Add(-time.Minute)))
+	assert.Equal(t, "30m0s before sunset at 51.5074, -0.1278 with 1h0m0s duration", s.String())
+
+	// on weekdays only, 2024-06-22 is a Saturday
+	s = SolarScheduler(CivilDawn, 51.5074, -0.1278, 0).WithLoc(london).
+		DayOfWeek(From(time.Monday).To(time.Friday))
+	assertNear(t, time.Date(2024, 6, 24, 3, 55, 0, 0, london), s.Next(time.Date(2024, 6, 22, 0, 0, 0, 0, london)))
+	assertNear(t, time.Date(2024, 6, 21, 3, 55, 0, 0, london), s.Previous(time.Date(2024, 6, 23, 23, 0, 0, 0, london)))
+	assert.Equal(t, "at civil dawn at 51.5074, -0.1278, from Monday through Friday", s.String())
+
+	// no sunrise during polar night
+	s = SolarScheduler(Sunrise, 69.6492, 18.9553, 0).WithLoc(time.UTC)
+	next = s.Next(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC))
+	assert.NotNil(t, next)
+	assert.True(t, next.After(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSchedule_SolarJSON(t *testing.T) {
+	s := SolarScheduler(Sunset, 51.5074, -0.1278, -30*time.Minute).WithLoc(time.UTC)
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	fromJSON, err := ScheduleFromJSON(string(data))
+	assert.NoError(t, err)
+	assert.Equal(t, s.String(), fromJSON.String())
+	from := time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, s.Next(from), fromJSON.Next(from))
+}
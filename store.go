@@ -0,0 +1,186 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultHistoryLimit is how many runs a store keeps per job by default.
+const DefaultHistoryLimit = 100
+
+// Run is an attempt at running a job, recorded once it returns.
+type Run struct {
+	Occurrence time.Time `json:"occurrence"`
+	// Attempt is 1 for the first attempt and counts up on retries.
+	Attempt int       `json:"attempt"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Error   string    `json:"error,omitempty"`
+}
+
+// JobState is the state a Store keeps for a job.
+type JobState struct {
+	LastRun *time.Time `json:"last_run,omitempty"`
+	NextRun *time.Time `json:"next_run,omitempty"`
+	History []Run      `json:"history,omitempty"`
+}
+
+// Store persists the state of jobs by job id, so that a Runner catches up
+// on the occurrences missed while it was not running.
+type Store interface {
+	LastRun(id string) (*time.Time, error)
+	SetLastRun(id string, t time.Time) error
+	NextRun(id string) (*time.Time, error)
+	// SetNextRun records the next run, nil when there is none.
+	SetNextRun(id string, t *time.Time) error
+	// History returns the runs recorded, oldest first.
+	History(id string) ([]Run, error)
+	AddRun(id string, run Run) error
+}
+
+// MemoryStore keeps the state of jobs in memory, and the last Limit runs of
+// each.
+type MemoryStore struct {
+	mu     sync.Mutex
+	Limit  int
+	states map[string]*JobState
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		Limit:  DefaultHistoryLimit,
+		states: make(map[string]*JobState),
+	}
+}
+
+func (m *MemoryStore) state(id string) *JobState {
+	if m.states == nil {
+		m.states = make(map[string]*JobState)
+	}
+	if m.states[id] == nil {
+		m.states[id] = &JobState{}
+	}
+	return m.states[id]
+}
+
+func (m *MemoryStore) LastRun(id string) (*time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state(id).LastRun, nil
+}
+
+func (m *MemoryStore) SetLastRun(id string, t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state(id).LastRun = &t
+	return nil
+}
+
+func (m *MemoryStore) NextRun(id string) (*time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state(id).NextRun, nil
+}
+
+func (m *MemoryStore) SetNextRun(id string, t *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state(id).NextRun = t
+	return nil
+}
+
+func (m *MemoryStore) History(id string) ([]Run, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Run{}, m.state(id).History...), nil
+}
+
+func (m *MemoryStore) AddRun(id string, run Run) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.state(id)
+	s.History = append(s.History, run)
+	if m.Limit > 0 && len(s.History) > m.Limit {
+		s.History = append([]Run{}, s.History[len(s.History)-m.Limit:]...)
+	}
+	return nil
+}
+
+// FileStore keeps the state of jobs in memory and writes it to a JSON file
+// on every change. The file is replaced atomically, so it is never left
+// half written.
+type FileStore struct {
+	*MemoryStore
+	path string
+	// save serializes the writes of the file.
+	save sync.Mutex
+}
+
+// NewFileStore returns a store writing to path, loading the state saved
+// there if any.
+func NewFileStore(path string) (*FileStore, error) {
+	f := &FileStore{
+		MemoryStore: NewMemoryStore(),
+		path:        path,
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &f.states); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileStore) SetLastRun(id string, t time.Time) error {
+	_ = f.MemoryStore.SetLastRun(id, t)
+	return f.write()
+}
+
+func (f *FileStore) SetNextRun(id string, t *time.Time) error {
+	_ = f.MemoryStore.SetNextRun(id, t)
+	return f.write()
+}
+
+func (f *FileStore) AddRun(id string, run Run) error {
+	_ = f.MemoryStore.AddRun(id, run)
+	return f.write()
+}
+
+// write writes the state to a temporary file next to the file, then
+// renames it over the file.
+func (f *FileStore) write() error {
+	f.save.Lock()
+	defer f.save.Unlock()
+	f.mu.Lock()
+	data, err := json.Marshal(f.states)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
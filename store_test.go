@@ -0,0 +1,157 @@
+package timewalk
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	m := NewMemoryStore()
+	last, err := m.LastRun("job")
+	assert.NoError(t, err)
+	assert.Nil(t, last)
+
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, m.SetLastRun("job", at))
+	assert.NoError(t, m.SetNextRun("job", ptr(at.Add(time.Hour))))
+	last, _ = m.LastRun("job")
+	assert.Equal(t, &at, last)
+	next, _ := m.NextRun("job")
+	assert.Equal(t, ptr(at.Add(time.Hour)), next)
+	assert.NoError(t, m.SetNextRun("job", nil))
+	next, _ = m.NextRun("job")
+	assert.Nil(t, next)
+
+	m.Limit = 2
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, m.AddRun("job", Run{Occurrence: at.Add(time.Duration(i) * time.Hour)}))
+	}
+	history, err := m.History("job")
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, at.Add(time.Hour), history[0].Occurrence)
+	history, _ = m.History("other")
+	assert.Empty(t, history)
+}
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	f, err := NewFileStore(path)
+	assert.NoError(t, err)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.SetLastRun("job", at))
+	assert.NoError(t, f.SetNextRun("job", ptr(at.Add(time.Hour))))
+	assert.NoError(t, f.AddRun("job", Run{Occurrence: at, Start: at, End: at.Add(time.Second), Error: "failed"}))
+
+	f, err = NewFileStore(path)
+	assert.NoError(t, err)
+	last, _ := f.LastRun("job")
+	assert.True(t, at.Equal(*last))
+	next, _ := f.NextRun("job")
+	assert.True(t, at.Add(time.Hour).Equal(*next))
+	history, _ := f.History("job")
+	assert.Len(t, history, 1)
+	assert.Equal(t, "failed", history[0].Error)
+
+	// no temporary file is left behind
+	files, err := os.ReadDir(filepath.Dir(path))
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+	_, err = NewFileStore(path)
+	assert.Error(t, err)
+}
+
+func TestRunner_Store(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.json")
+	store, err := NewFileStore(path)
+	assert.NoError(t, err)
+	r := NewRunner().WithStore(store)
+	ran := make(chan struct{}, 10)
+	assert.NoError(t, r.Add("job", Scheduler(), func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}))
+	r.Start(context.Background())
+	<-ran
+	assert.NoError(t, r.Stop(context.Background()))
+	e := r.Entry("job")
+	assert.NoError(t, e.StoreErr)
+
+	// a new runner picks up from the file
+	store, err = NewFileStore(path)
+	assert.NoError(t, err)
+	last, _ := store.LastRun("job")
+	assert.True(t, e.Prev.Equal(*last))
+	history, _ := store.History("job")
+	assert.NotEmpty(t, history)
+	r = NewRunner().WithStore(store)
+	assert.NoError(t, r.Add("job", Scheduler(), nil, WithCatchUp(CatchUpSkip, 0)))
+	assert.True(t, e.Prev.Equal(*r.Entry("job").Prev))
+	next, _ := store.NextRun("job")
+	assert.True(t, r.Entry("job").Next.Equal(*next))
+}
+
+type failingStore struct {
+	*MemoryStore
+	err error
+}
+
+func (f *failingStore) SetNextRun(id string, t *time.Time) error {
+	if f.err != nil {
+		return f.err
+	}
+	return f.MemoryStore.SetNextRun(id, t)
+}
+
+func TestRunner_StoreErr(t *testing.T) {
+	store := &failingStore{MemoryStore: NewMemoryStore(), err: errors.New("disk full")}
+	r := NewRunner().WithStore(store)
+	assert.NoError(t, r.Add("job", Scheduler(), nil))
+	assert.EqualError(t, r.Entry("job").StoreErr, "disk full")
+
+	// cleared by the next write succeeding
+	store.err = nil
+	r.mu.Lock()
+	r.saveNext(r.find("job"))
+	r.mu.Unlock()
+	r.flush()
+	assert.NoError(t, r.Entry("job").StoreErr)
+}
+
+type blockingStore struct {
+	*MemoryStore
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingStore) SetNextRun(id string, t *time.Time) error {
+	select {
+	case b.writing <- struct{}{}:
+	default:
+	}
+	<-b.release
+	return b.MemoryStore.SetNextRun(id, t)
+}
+
+func TestRunner_StoreUnlocked(t *testing.T) {
+	store := &blockingStore{MemoryStore: NewMemoryStore(), writing: make(chan struct{}, 10), release: make(chan struct{})}
+	r := NewRunner().WithStore(store)
+	added := make(chan error)
+	go func() {
+		added <- r.Add("job", Scheduler(), nil)
+	}()
+	// the runner is not held up by the write in progress
+	<-store.writing
+	assert.Len(t, r.Entries(), 1)
+	close(store.release)
+	assert.NoError(t, <-added)
+	next, _ := store.NextRun("job")
+	assert.Equal(t, r.Entry("job").Next, next)
+}
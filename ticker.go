@@ -0,0 +1,118 @@
+package timewalk
+
+import (
+	"sync"
+	"time"
+)
+
+// maxTickWait bounds how long a Ticker sleeps before checking the clock
+// again, so that it notices the clock jumping forward.
+const maxTickWait = time.Minute
+
+// Ticker delivers the occurrences of a schedule on C, like a time.Ticker.
+// When the clock jumps forward or the reader is slow, the occurrences
+// fallen in the past are dropped but for the latest. When the clock jumps
+// back, the Ticker waits for the next occurrence from the new time.
+type Ticker struct {
+	C     <-chan time.Time
+	c     chan time.Time
+	clock Clock
+	reset chan func(t time.Time) *time.Time
+	stop  chan struct{}
+	once  sync.Once
+}
+
+// NewTicker returns a Ticker delivering the occurrences of s.
+func NewTicker(s *Schedule, opts ...Option) *Ticker {
+	return newTicker(s.Next, opts)
+}
+
+// NewSchedulersTicker returns a Ticker delivering the occurrences of the
+// enabled schedules of s.
+func NewSchedulersTicker(s Schedulers, opts ...Option) *Ticker {
+	return newTicker(s.next, opts)
+}
+
+func newTicker(next func(t time.Time) *time.Time, opts []Option) *Ticker {
+	c := make(chan time.Time, 1)
+	t := &Ticker{
+		C:     c,
+		c:     c,
+		clock: newOptions(opts).clock,
+		reset: make(chan func(t time.Time) *time.Time),
+		stop:  make(chan struct{}),
+	}
+	go t.loop(next)
+	return t
+}
+
+// Stop turns off the ticker. It does not close C.
+func (t *Ticker) Stop() {
+	t.once.Do(func() {
+		close(t.stop)
+	})
+}
+
+// Reset makes the ticker deliver the occurrences of s from now on.
+func (t *Ticker) Reset(s *Schedule) {
+	t.resetNext(s.Next)
+}
+
+// ResetSchedulers makes the ticker deliver the occurrences of the enabled
+// schedules of s from now on.
+func (t *Ticker) ResetSchedulers(s Schedulers) {
+	t.resetNext(s.next)
+}
+
+func (t *Ticker) resetNext(next func(t time.Time) *time.Time) {
+	select {
+	case t.reset <- next:
+	case <-t.stop:
+	}
+}
+
+func (t *Ticker) loop(next func(t time.Time) *time.Time) {
+	at := next(ceil(t.clock.Now()))
+	for {
+		var timer Timer
+		var fire <-chan time.Time
+		if at != nil {
+			wait := at.Sub(t.clock.Now())
+			if wait > maxTickWait {
+				wait = maxTickWait
+			}
+			timer = t.clock.NewTimer(wait)
+			fire = timer.C()
+		}
+		select {
+		case <-t.stop:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case next = <-t.reset:
+			if timer != nil {
+				timer.Stop()
+			}
+			at = next(ceil(t.clock.Now()))
+			continue
+		case <-fire:
+		}
+		now := t.clock.Now()
+		if now.Before(*at) {
+			// woken up early or the clock went back
+			at = next(ceil(now))
+			continue
+		}
+		// keep the latest occurrence fallen in the past
+		tick := *at
+		for at != nil && !at.After(now) {
+			tick = *at
+			at = next(at.Truncate(time.Second).Add(time.Second))
+		}
+		select {
+		case t.c <- tick:
+		default:
+		}
+	}
+}
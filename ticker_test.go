@@ -0,0 +1,62 @@
+package timewalk_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vuho-pg/timewalk"
+	"github.com/vuho-pg/timewalk/timewalktest"
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := timewalktest.NewFakeClock(start)
+	hourly := timewalk.Scheduler().WithLoc(time.UTC).Minute(timewalk.At(30)).Second(timewalk.At(0))
+	ticker := timewalk.NewTicker(hourly, timewalk.WithClock(c))
+	defer ticker.Stop()
+
+	// one hour a minute at a time
+	for i := 0; i < 30; i++ {
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+	}
+	assert.Equal(t, start.Add(30*time.Minute), <-ticker.C)
+
+	// the clock jumps forward, only the latest occurrence is delivered
+	c.BlockUntil(1)
+	c.Set(start.Add(5*time.Hour + 45*time.Minute))
+	assert.Equal(t, start.Add(5*time.Hour+30*time.Minute), <-ticker.C)
+
+	// the clock jumps back
+	c.BlockUntil(1)
+	c.Set(start.Add(2 * time.Hour))
+	for c.Now().Before(start.Add(2*time.Hour + 30*time.Minute)) {
+		c.BlockUntil(1)
+		next, _ := c.Next()
+		c.Set(next)
+	}
+	assert.Equal(t, start.Add(2*time.Hour+30*time.Minute), <-ticker.C)
+
+	// schedulers
+	a := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(3)).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	b := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(4)).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	a.Enable, b.Enable = true, true
+	ticker.ResetSchedulers(timewalk.Schedulers{a, b})
+	for i := 0; i < 120; i++ {
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		if i == 59 {
+			assert.Equal(t, start.Add(3*time.Hour), <-ticker.C)
+		}
+	}
+	assert.Equal(t, start.Add(4*time.Hour), <-ticker.C)
+
+	// no occurrence left
+	never := timewalk.Scheduler().Year(timewalk.At(2000))
+	never.Enable = true
+	ticker.ResetSchedulers(timewalk.Schedulers{never})
+	c.Advance(24 * time.Hour)
+	assert.Len(t, ticker.C, 0)
+	ticker.Stop()
+	ticker.Stop()
+}
@@ -13,6 +13,7 @@ const (
 	TValue   UnitType = 1 << iota
 	TRange
 	TStep
+	THash
 )
 
 func (u UnitType) Is(t UnitType) bool {
@@ -29,6 +30,7 @@ type Unit[T TimeUnit] struct {
 	ValueFrom *T       `json:"value_from,omitempty"`
 	ValueTo   *T       `json:"value_to,omitempty"`
 	ValueStep *T       `json:"value_step,omitempty"`
+	Key       string   `json:"key,omitempty"`
 }
 
 func (u *Unit[T]) At(value T) *Unit[T] {
@@ -49,6 +51,40 @@ func Every[T TimeUnit](step T) *Unit[T] {
 	return ptr(Unit[T]{}).Every(step)
 }
 
+// Hash returns a unit picking a stable value from from through to out of
+// key, like Jenkins' H. Combined with Every, the hash picks the offset of
+// the steps instead.
+func Hash[T TimeUnit](key string, from T, to T) *Unit[T] {
+	return ptr(Unit[T]{}).Hash(key, from, to)
+}
+
+// Hash picks a value from from to to, both included, stable for key. An
+// inverted range is swapped.
+func (u *Unit[T]) Hash(key string, from T, to T) *Unit[T] {
+	if to < from {
+		from, to = to, from
+	}
+	u.Type |= THash | TRange
+	u.Key = key
+	u.ValueFrom = &from
+	u.ValueTo = &to
+	return u
+}
+
+// resolve returns the plain unit a hash unit stands for.
+func (u *Unit[T]) resolve() *Unit[T] {
+	h := int(hash(u.Key) % (1 << 31))
+	from, to := *u.ValueFrom, *u.ValueTo
+	if to < from {
+		// e.g. read from JSON
+		from, to = to, from
+	}
+	if u.Type.Is(TStep) && *u.ValueStep > 0 {
+		return From(from + T(h%int(*u.ValueStep))).To(to).Every(*u.ValueStep)
+	}
+	return At(from + T(h%int(to-from+1)))
+}
+
 func (u *Unit[T]) Every(step T) *Unit[T] {
 	u.Type |= TStep
 	u.ValueStep = &step
@@ -69,6 +105,11 @@ func (u *Unit[T]) To(value T) *Unit[T] {
 
 func (u *Unit[T]) String(unitName string) string {
 	b := strings.Builder{}
+	if u.Type.Is(THash) {
+		b.WriteString(u.resolve().String(unitName))
+		b.WriteString(fmt.Sprintf(" hashed from %q", u.Key))
+		return b.String()
+	}
 	if u.Type.Is(TStep) && u.ValueStep != nil {
 		b.WriteString("every ")
 		b.WriteString(fmt.Sprint(*u.ValueStep))
@@ -94,6 +135,9 @@ func (u *Unit[T]) String(unitName string) string {
 }
 
 func (u *Unit[T]) Match(data T) bool {
+	if u.Type.Is(THash) {
+		return u.resolve().Match(data)
+	}
 	if u.Type.Is(TValue) {
 		return *u.Value == data
 	}
@@ -118,6 +162,9 @@ func (u *Unit[T]) Match(data T) bool {
 }
 
 func (u *Unit[T]) Next(data T) T {
+	if u.Type.Is(THash) {
+		return u.resolve().Next(data)
+	}
 	if u.Type.Is(TValue) {
 		if *u.Value >= data {
 			return *u.Value
@@ -173,6 +220,9 @@ func (u *Unit[T]) Previous(data T) T {
 	// / step
 	// o data
 
+	if u.Type.Is(THash) {
+		return u.resolve().Previous(data)
+	}
 	if u.Type.Is(TValue) {
 		// * o
 		if *u.Value <= data {
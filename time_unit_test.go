@@ -3,6 +3,7 @@ package timewalk
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestUnit_String(t *testing.T) {
@@ -182,3 +183,56 @@ func TestUnit_Next(t *testing.T) {
 	}
 	assert.Equal(t, -1, u.Next(0))
 }
+
+func TestUnit_Hash(t *testing.T) {
+	h := Hash("billing", 0, 59)
+	v := h.Next(0)
+	assert.True(t, v >= 0 && v <= 59)
+	// stable
+	assert.Equal(t, v, Hash("billing", 0, 59).Next(0))
+	assert.True(t, h.Match(v))
+	assert.False(t, h.Match((v+1)%60))
+	assert.Equal(t, v, h.Previous(59))
+	assert.Equal(t, -1, h.Next(v+1))
+	assert.Equal(t, "at "+ordinalSuffix(v, "minute")+` hashed from "billing"`, h.String("minute"))
+
+	// keys spread over the range
+	seen := make(map[int]bool)
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		seen[Hash(key, 0, 59).Next(0)] = true
+	}
+	assert.True(t, len(seen) > 1)
+
+	// with step the hash picks the offset
+	hs := Hash("billing", 0, 59).Every(15)
+	offset := hs.Next(0)
+	assert.True(t, offset >= 0 && offset < 15)
+	assert.Equal(t, offset+15, hs.Next(offset+1))
+	assert.Equal(t, offset+45, hs.Previous(59))
+	assert.True(t, hs.Match(offset+30))
+}
+
+func TestUnit_HashInverted(t *testing.T) {
+	// swapped into range
+	assert.Equal(t, Hash("billing", 0, 59).Next(0), Hash("billing", 59, 0).Next(0))
+	assert.NotPanics(t, func() {
+		Hash("billing", 10, 9).Next(0)
+	})
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		v := Hash(key, 20, 10).Next(0)
+		assert.True(t, v >= 10 && v <= 20, v)
+	}
+	// inverted in JSON
+	u := &Unit[int]{Type: THash | TRange, Key: "billing", ValueFrom: ptr(59), ValueTo: ptr(0)}
+	assert.Equal(t, Hash("billing", 0, 59).Next(0), u.Next(0))
+}
+
+func TestSchedule_Hash(t *testing.T) {
+	s := Scheduler().WithLoc(time.UTC).Minute(Hash[int]("billing", 0, 59)).Second(At(0))
+	minute := Hash("billing", 0, 59).Next(0)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := s.Next(from)
+	assert.Equal(t, from.Add(time.Duration(minute)*time.Minute), *next)
+	assert.Equal(t, next.Add(time.Hour), *s.NextAfter(*next))
+	assert.Equal(t, *next, *s.Previous(from.Add(time.Hour)))
+}
@@ -0,0 +1,148 @@
+// Package timewalktest provides helpers to test code built on timewalk.
+package timewalktest
+
+import (
+	"github.com/vuho-pg/timewalk"
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a timewalk.Clock whose time only moves when told to. Timers
+// fire, in order of their deadline, as Advance or Set moves the time past
+// it.
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) NewTimer(d time.Duration) timewalk.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.schedule(t, d)
+	return t
+}
+
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Advance moves the time forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(c.now.Add(d))
+}
+
+// Set moves the time to t. Timers due by t fire, the time moving to the
+// deadline of each before it fires, and is t once Set returns. Moving the
+// time back keeps the time left on pending timers, as the clock jumping
+// back does not delay the timers of the time package.
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if back := c.now.Sub(t); back > 0 {
+		for _, timer := range c.timers {
+			timer.at = timer.at.Add(-back)
+		}
+	}
+	c.set(t)
+}
+
+func (c *FakeClock) set(t time.Time) {
+	for len(c.timers) > 0 && !c.timers[0].at.After(t) {
+		timer := c.timers[0]
+		c.timers = c.timers[1:]
+		if timer.at.After(c.now) {
+			c.now = timer.at
+		}
+		select {
+		case timer.c <- c.now:
+		default:
+		}
+	}
+	c.now = t
+}
+
+// Next returns the deadline of the earliest pending timer, if any.
+func (c *FakeClock) Next() (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.timers) == 0 {
+		return time.Time{}, false
+	}
+	return c.timers[0].at, true
+}
+
+// BlockUntil waits until n timers are pending, e.g. for the code under
+// test to wait for its next deadline before moving the time.
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// schedule makes t pending until d from now.
+func (c *FakeClock) schedule(t *fakeTimer, d time.Duration) {
+	t.at = c.now.Add(d)
+	c.timers = append(c.timers, t)
+	sort.SliceStable(c.timers, func(i, j int) bool {
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+	c.cond.Broadcast()
+	if d <= 0 {
+		c.set(c.now)
+	}
+}
+
+// unschedule removes t from the pending timers and reports whether it was.
+func (c *FakeClock) unschedule(t *fakeTimer) bool {
+	for i, v := range c.timers {
+		if v == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	c     chan time.Time
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.unschedule(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.clock.unschedule(t)
+	t.clock.schedule(t, d)
+	return active
+}
@@ -0,0 +1,91 @@
+package timewalktest
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/vuho-pg/timewalk"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	var _ timewalk.Clock = c
+	assert.Equal(t, start, c.Now())
+
+	late := c.NewTimer(2 * time.Hour)
+	early := c.After(time.Hour)
+	stopped := c.NewTimer(30 * time.Minute)
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+	next, ok := c.Next()
+	assert.True(t, ok)
+	assert.Equal(t, start.Add(time.Hour), next)
+
+	c.Advance(90 * time.Minute)
+	assert.Equal(t, start.Add(time.Hour), <-early)
+	assert.Len(t, late.C(), 0)
+	assert.Equal(t, start.Add(90*time.Minute), c.Now())
+
+	assert.True(t, late.Reset(time.Hour))
+	c.Set(start.Add(3 * time.Hour))
+	assert.Equal(t, start.Add(150*time.Minute), <-late.C())
+	_, ok = c.Next()
+	assert.False(t, ok)
+
+	// expired timers fire right away
+	assert.Equal(t, start.Add(3*time.Hour), <-c.After(0))
+
+	// the time may move backwards
+	c.Set(start)
+	assert.Equal(t, start, c.Now())
+}
+
+func TestFakeClock_BlockUntil(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-c.After(time.Minute)
+	}()
+	c.BlockUntil(1)
+	c.Advance(time.Minute)
+	<-done
+}
+
+func TestFakeClock_Runner(t *testing.T) {
+	// a month of daily runs in milliseconds
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	store := timewalk.NewMemoryStore()
+	r := timewalk.NewRunner(timewalk.WithClock(c)).WithStore(store)
+	s := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(9)).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	var runs int32
+	assert.NoError(t, r.Add("daily", s, func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}))
+	r.Start(context.Background())
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	for {
+		c.BlockUntil(1)
+		next, _ := c.Next()
+		if next.After(end) {
+			break
+		}
+		c.Set(next)
+	}
+	assert.NoError(t, r.Stop(context.Background()))
+	assert.Equal(t, int32(31), atomic.LoadInt32(&runs))
+	history, err := store.History("daily")
+	assert.NoError(t, err)
+	assert.Len(t, history, 31)
+	for _, run := range history {
+		assert.False(t, run.Start.Before(run.Occurrence))
+	}
+	last, _ := store.LastRun("daily")
+	assert.Equal(t, time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC), *last)
+	assert.Equal(t, 0, r.Entry("daily").Missed)
+}
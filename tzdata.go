@@ -0,0 +1,7 @@
+//go:build timewalk_tzdata
+
+package timewalk
+
+// Embed the zone database, about 450KB, so locations load on systems
+// without zoneinfo. Build with the timewalk_tzdata tag to opt in.
+import _ "time/tzdata"
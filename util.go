@@ -2,9 +2,14 @@ package timewalk
 
 import (
 	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strconv"
 	"time"
 )
 
+var fixedOffset = regexp.MustCompile(`^(?:UTC|GMT)?([+-])(\d{1,2})(?::?(\d{2}))?$`)
+
 func maxDay(year int, month time.Month) int {
 	switch month {
 
@@ -80,3 +85,32 @@ func intersect[T comparable](arrA []T, arrB []T) []T {
 	}
 	return res
 }
+
+// loadLocation loads an IANA zone or a fixed offset such as "UTC+07:00",
+// "GMT-5" or "+0530". IANA zones come from the system zoneinfo, or from the
+// embedded database when built with the timewalk_tzdata tag.
+func loadLocation(name string) (*time.Location, error) {
+	m := fixedOffset.FindStringSubmatch(name)
+	if m == nil {
+		return time.LoadLocation(name)
+	}
+	hour, _ := strconv.Atoi(m[2])
+	minute := 0
+	if m[3] != "" {
+		minute, _ = strconv.Atoi(m[3])
+	}
+	if hour > 14 || minute > 59 {
+		return nil, fmt.Errorf("timewalk: invalid offset %q", name)
+	}
+	offset := hour*60*60 + minute*60
+	if m[1] == "-" {
+		offset = -offset
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%s%02d:%02d", m[1], hour, minute), offset), nil
+}
+
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
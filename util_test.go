@@ -87,3 +87,27 @@ func Test_intersect(t *testing.T) {
 	arrB = []int{6, 7, 8, 9, 10}
 	assert.Equal(t, []int{}, intersect(arrA, arrB))
 }
+
+func Test_loadLocation(t *testing.T) {
+	offsets := map[string]int{
+		"UTC+07:00": 7 * 60 * 60,
+		"UTC+7":     7 * 60 * 60,
+		"GMT-5":     -5 * 60 * 60,
+		"+0530":     5*60*60 + 30*60,
+		"-09:30":    -(9*60*60 + 30*60),
+		"UTC+00:00": 0,
+	}
+	for name, offset := range offsets {
+		loc, err := loadLocation(name)
+		assert.NoError(t, err, name)
+		_, got := time.Now().In(loc).Zone()
+		assert.Equal(t, offset, got, name)
+	}
+	loc, err := loadLocation("Europe/London")
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/London", loc.String())
+	_, err = loadLocation("UTC+15")
+	assert.Error(t, err)
+	_, err = loadLocation("Asia/HoChiMinh")
+	assert.Error(t, err)
+}
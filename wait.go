@@ -0,0 +1,63 @@
+package timewalk
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoOccurrence = errors.New("timewalk: no occurrence left")
+
+// SleepUntilNext blocks until the next occurrence of s and returns it, or
+// returns ctx.Err() if ctx is done first.
+func SleepUntilNext(ctx context.Context, s *Schedule, opts ...Option) (time.Time, error) {
+	clock := newOptions(opts).clock
+	next := s.Next(ceil(clock.Now()))
+	if next == nil {
+		return time.Time{}, ErrNoOccurrence
+	}
+	if err := sleepUntil(ctx, clock, *next); err != nil {
+		return time.Time{}, err
+	}
+	return *next, nil
+}
+
+// WaitForWindow blocks until s is in progress, or returns ctx.Err() if ctx
+// is done first.
+func WaitForWindow(ctx context.Context, s Schedulers, opts ...Option) error {
+	clock := newOptions(opts).clock
+	for {
+		now := clock.Now()
+		if s.InProgress(now) {
+			return nil
+		}
+		next := s.NextTransition(now)
+		if next == nil {
+			return ErrNoOccurrence
+		}
+		if err := sleepUntil(ctx, clock, next.Time); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepUntil blocks until the clock reads t, checking it at least every
+// maxTickWait to notice it jumping.
+func sleepUntil(ctx context.Context, clock Clock, t time.Time) error {
+	for {
+		wait := t.Sub(clock.Now())
+		if wait <= 0 {
+			return nil
+		}
+		if wait > maxTickWait {
+			wait = maxTickWait
+		}
+		timer := clock.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package timewalk_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/vuho-pg/timewalk"
+	"github.com/vuho-pg/timewalk/timewalktest"
+	"testing"
+	"time"
+)
+
+func TestSleepUntilNext(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := timewalktest.NewFakeClock(start)
+	s := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(9)).Minute(timewalk.At(0)).Second(timewalk.At(0))
+	done := make(chan time.Time)
+	go func() {
+		at, err := timewalk.SleepUntilNext(context.Background(), s, timewalk.WithClock(c))
+		assert.NoError(t, err)
+		done <- at
+	}()
+	for c.Now().Before(start.Add(9 * time.Hour)) {
+		c.BlockUntil(1)
+		next, _ := c.Next()
+		c.Set(next)
+	}
+	assert.Equal(t, start.Add(9*time.Hour), <-done)
+	assert.Equal(t, start.Add(9*time.Hour), c.Now())
+
+	c.Advance(time.Second)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error)
+	go func() {
+		_, err := timewalk.SleepUntilNext(ctx, s, timewalk.WithClock(c))
+		errs <- err
+	}()
+	c.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	_, err := timewalk.SleepUntilNext(context.Background(), timewalk.Scheduler().Year(timewalk.At(2000)), timewalk.WithClock(c))
+	assert.ErrorIs(t, err, timewalk.ErrNoOccurrence)
+}
+
+func TestWaitForWindow(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := timewalktest.NewFakeClock(start)
+	s := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(9)).Minute(timewalk.At(0)).Second(timewalk.At(0)).
+		WithDuration(time.Hour)
+	s.Enable = true
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- timewalk.WaitForWindow(ctx, timewalk.Schedulers{s}, timewalk.WithClock(c))
+	}()
+	// the clock jumps into the window
+	c.BlockUntil(1)
+	c.Set(start.Add(9*time.Hour + 30*time.Minute))
+	assert.NoError(t, <-done)
+
+	go func() {
+		done <- timewalk.WaitForWindow(ctx, timewalk.Schedulers{s}, timewalk.WithClock(c))
+	}()
+	assert.NoError(t, <-done)
+
+	c.Set(start.Add(11 * time.Hour))
+	go func() {
+		done <- timewalk.WaitForWindow(ctx, timewalk.Schedulers{s}, timewalk.WithClock(c))
+	}()
+	c.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	s.Year(timewalk.At(2000))
+	assert.ErrorIs(t, timewalk.WaitForWindow(context.Background(), timewalk.Schedulers{s}, timewalk.WithClock(c)), timewalk.ErrNoOccurrence)
+}
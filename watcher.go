@@ -0,0 +1,95 @@
+package timewalk
+
+import (
+	"context"
+	"time"
+)
+
+// WindowFunc is called when a window opens or closes, with the schedule
+// opening or closing it.
+type WindowFunc func(s *Schedule, at time.Time)
+
+// Watcher calls back when the enabled schedules of Schedulers go in and out
+// of progress. Overlapping windows chain: OnExit is only called once none
+// of them is in progress.
+type Watcher struct {
+	Schedulers Schedulers
+	clock      Clock
+	onEnter    WindowFunc
+	onExit     WindowFunc
+}
+
+func NewWatcher(s Schedulers, opts ...Option) *Watcher {
+	return &Watcher{
+		Schedulers: s,
+		clock:      newOptions(opts).clock,
+	}
+}
+
+func (w *Watcher) OnEnter(fn WindowFunc) *Watcher {
+	w.onEnter = fn
+	return w
+}
+
+func (w *Watcher) OnExit(fn WindowFunc) *Watcher {
+	w.onExit = fn
+	return w
+}
+
+// Run calls back at each transition until ctx is done, and returns
+// ctx.Err(). Callbacks are called on the goroutine of Run, with the
+// instant of the transition. When Run starts within a window, OnEnter is
+// called right away with the schedule of the latest window. OnExit is
+// called with the schedule closing the window, or the one OnEnter was
+// called with when it is unknown, e.g. as the clock jumped.
+func (w *Watcher) Run(ctx context.Context) error {
+	active := false
+	var next *Transition
+	// entered is the schedule OnEnter was last called with.
+	var entered *Schedule
+	for {
+		now := w.clock.Now()
+		if in := w.Schedulers.InProgress(now); in != active {
+			tr := next
+			if tr == nil || tr.Open != in {
+				tr = w.current(now, in)
+			}
+			if in {
+				entered = tr.Schedule
+			} else if tr.Schedule == nil {
+				tr = &Transition{Time: tr.Time, Open: false, Schedule: entered}
+			}
+			if in && w.onEnter != nil {
+				w.onEnter(tr.Schedule, tr.Time)
+			}
+			if !in && w.onExit != nil {
+				w.onExit(tr.Schedule, tr.Time)
+			}
+			active = in
+		}
+		next = w.Schedulers.NextTransition(now)
+		if next == nil {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		if err := sleepUntil(ctx, w.clock, next.Time); err != nil {
+			return err
+		}
+	}
+}
+
+// current returns the transition to in at now that was not expected, e.g.
+// on start or as the clock jumped, opened by the latest window. Closing
+// ones have no schedule, Run fills in the one last entered.
+func (w *Watcher) current(now time.Time, in bool) *Transition {
+	res := &Transition{Time: now, Open: in}
+	var start time.Time
+	if in {
+		for _, win := range w.Schedulers.windowsAt(now) {
+			if res.Schedule == nil || win.Start.After(start) {
+				res.Schedule, start = win.Schedule, win.Start
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,117 @@
+package timewalk_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/vuho-pg/timewalk"
+	"github.com/vuho-pg/timewalk/timewalktest"
+	"testing"
+	"time"
+)
+
+type event struct {
+	enter    bool
+	schedule *timewalk.Schedule
+	at       time.Time
+}
+
+func TestWatcher(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := func(hour int, d time.Duration) *timewalk.Schedule {
+		s := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(hour)).Minute(timewalk.At(0)).Second(timewalk.At(0)).
+			WithDuration(d)
+		s.Enable = true
+		return s
+	}
+	// 09:00 to 11:00 and 10:00 to 12:00 overlap
+	a, b, c := window(9, 2*time.Hour), window(10, 2*time.Hour), window(15, time.Hour)
+	clock := timewalktest.NewFakeClock(day.Add(9*time.Hour + 30*time.Minute))
+	events := make(chan event, 10)
+	w := timewalk.NewWatcher(timewalk.Schedulers{a, b, c}, timewalk.WithClock(clock)).
+		OnEnter(func(s *timewalk.Schedule, at time.Time) {
+			events <- event{true, s, at}
+		}).
+		OnExit(func(s *timewalk.Schedule, at time.Time) {
+			events <- event{false, s, at}
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+
+	// started within the window
+	assert.Equal(t, event{true, a, day.Add(9*time.Hour + 30*time.Minute)}, <-events)
+	for clock.Now().Before(day.Add(33 * time.Hour)) {
+		clock.BlockUntil(1)
+		next, _ := clock.Next()
+		clock.Set(next)
+	}
+	assert.Equal(t, event{false, b, day.Add(12 * time.Hour)}, <-events)
+	assert.Equal(t, event{true, c, day.Add(15 * time.Hour)}, <-events)
+	assert.Equal(t, event{false, c, day.Add(16 * time.Hour)}, <-events)
+	assert.Equal(t, event{true, a, day.Add(33 * time.Hour)}, <-events)
+
+	// the clock jumps past the end of the window
+	clock.BlockUntil(1)
+	clock.Set(day.Add(38 * time.Hour))
+	assert.Equal(t, event{false, b, day.Add(36 * time.Hour)}, <-events)
+
+	clock.BlockUntil(1)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// never in progress
+	never := window(9, time.Hour).Year(timewalk.At(2000))
+	assert.ErrorIs(t, timewalk.NewWatcher(timewalk.Schedulers{never}, timewalk.WithClock(clock)).Run(ctx), context.Canceled)
+}
+
+// readClock reports the instants the clock is read at.
+type readClock struct {
+	*timewalktest.FakeClock
+	reads chan time.Time
+}
+
+func (c readClock) Now() time.Time {
+	now := c.FakeClock.Now()
+	select {
+	case c.reads <- now:
+	default:
+	}
+	return now
+}
+
+func TestWatcher_ExitAfterJump(t *testing.T) {
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// 09:00 to 11:00, ending at 10:00
+	end := day.Add(10 * time.Hour)
+	s := timewalk.Scheduler().WithLoc(time.UTC).Hour(timewalk.At(9)).Minute(timewalk.At(0)).Second(timewalk.At(0)).
+		WithDuration(2 * time.Hour).EndAt(&end)
+	s.Enable = true
+	clock := readClock{timewalktest.NewFakeClock(end), make(chan time.Time, 100)}
+	events := make(chan event, 10)
+	w := timewalk.NewWatcher(timewalk.Schedulers{s}, timewalk.WithClock(clock)).
+		OnEnter(func(s *timewalk.Schedule, at time.Time) {
+			events <- event{true, s, at}
+		}).
+		OnExit(func(s *timewalk.Schedule, at time.Time) {
+			events <- event{false, s, at}
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- w.Run(ctx)
+	}()
+	assert.Equal(t, event{true, s, end}, <-events)
+
+	// the window closes right away, with no schedule to close it, as the
+	// clock is read again
+	for i := 0; i < 3; i++ {
+		<-clock.reads
+	}
+	clock.Set(end.Add(time.Hour))
+	assert.Equal(t, event{false, s, end}, <-events)
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+}
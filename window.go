@@ -0,0 +1,122 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// forever ends windows that never close.
+var forever = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+// WindowSchedule is open from each occurrence of Open until the first
+// occurrence of Close strictly after it. Occurrences of Open while already
+// open do not start another window.
+type WindowSchedule struct {
+	Enable bool      `json:"enable"`
+	Open   *Schedule `json:"open"`
+	Close  *Schedule `json:"close"`
+}
+
+// Transition is an instant a window opens or closes at.
+type Transition struct {
+	Time time.Time
+	Open bool
+	// Schedule is the schedule opening or closing the window.
+	Schedule *Schedule
+}
+
+func NewWindowSchedule(open *Schedule, close *Schedule) *WindowSchedule {
+	return &WindowSchedule{
+		Open:  open,
+		Close: close,
+	}
+}
+
+func WindowScheduleFromJSON(data string, opts ...JSONOption) (*WindowSchedule, error) {
+	var w *WindowSchedule
+	if err := json.Unmarshal([]byte(data), &w); err != nil {
+		return nil, err
+	}
+	if w == nil || w.Open == nil || w.Close == nil {
+		return nil, errors.New("timewalk: window needs an open and a close schedule")
+	}
+	cfg := newJSONConfig(opts)
+	if err := cfg.check(w.Open); err != nil {
+		return nil, err
+	}
+	if err := cfg.check(w.Close); err != nil {
+		return nil, err
+	}
+	w.Open.once.Do(w.Open.correct)
+	w.Close.once.Do(w.Close.correct)
+	return w, nil
+}
+
+// window returns the window open at t, if any. It opens at the first
+// occurrence of Open after the last occurrence of Close at or before t.
+// Before any occurrence of Close, it opens at the first occurrence of Open
+// from the start of Open, or from the Unix epoch when it has none.
+func (w *WindowSchedule) window(t time.Time) *Window {
+	var open *time.Time
+	if close := w.Close.Previous(t); close != nil {
+		open = w.Open.Next(*close)
+	} else {
+		w.Open.once.Do(w.Open.correct)
+		from := time.Unix(0, 0)
+		if w.Open.StartTime != nil {
+			from = *w.Open.StartTime
+		}
+		open = w.Open.Next(from)
+	}
+	if open == nil || open.After(t) {
+		return nil
+	}
+	res := &Window{Schedule: w.Open, Start: *open}
+	if close := w.Close.NextAfter(*open); close != nil {
+		res.End = *close
+	} else {
+		res.End = forever
+	}
+	return res
+}
+
+func (w *WindowSchedule) InProgress(t time.Time) bool {
+	return w.window(t) != nil
+}
+
+// Windows returns the windows open at some point from from up to to.
+// Windows that never close end on the last second of year 9999.
+func (w *WindowSchedule) Windows(from, to time.Time) []Window {
+	res := make([]Window, 0)
+	next := w.Open.Next(from)
+	if cur := w.window(from); cur != nil {
+		res = append(res, *cur)
+		next = w.Open.Next(cur.End)
+	}
+	for next != nil && next.Before(to) {
+		cur := w.window(*next)
+		res = append(res, *cur)
+		next = w.Open.Next(cur.End)
+	}
+	return res
+}
+
+// NextTransition returns the first instant after t the window opens or
+// closes at, or nil when it stays as it is.
+func (w *WindowSchedule) NextTransition(t time.Time) *Transition {
+	if cur := w.window(t); cur != nil {
+		if close := w.Close.NextAfter(cur.Start); close != nil {
+			return &Transition{Time: *close, Open: false, Schedule: w.Close}
+		}
+		return nil
+	}
+	if open := w.Open.NextAfter(t); open != nil {
+		return &Transition{Time: *open, Open: true, Schedule: w.Open}
+	}
+	return nil
+}
+
+func (w *WindowSchedule) String() string {
+	return "from " + w.Open.String() + " until " + w.Close.String()
+}
@@ -0,0 +1,126 @@
+package timewalk
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func weekOpening() *WindowSchedule {
+	// open from Monday 09:00 until Friday 17:00
+	open := Scheduler().WithLoc(time.UTC).DayOfWeek(At(time.Monday)).Hour(At(9)).Minute(At(0)).Second(At(0))
+	close := Scheduler().WithLoc(time.UTC).DayOfWeek(At(time.Friday)).Hour(At(17)).Minute(At(0)).Second(At(0))
+	return NewWindowSchedule(open, close)
+}
+
+func TestWindowSchedule_InProgress(t *testing.T) {
+	w := weekOpening()
+	// 2024-01-01 is a Monday
+	assert.False(t, w.InProgress(time.Date(2024, 1, 1, 8, 59, 59, 0, time.UTC)))
+	assert.True(t, w.InProgress(time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)))
+	assert.True(t, w.InProgress(time.Date(2024, 1, 3, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, w.InProgress(time.Date(2024, 1, 5, 16, 59, 59, 0, time.UTC)))
+	assert.False(t, w.InProgress(time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC)))
+	assert.False(t, w.InProgress(time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestWindowSchedule_Windows(t *testing.T) {
+	w := weekOpening()
+	ws := w.Windows(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ws, 2)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), ws[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC), ws[0].End)
+	assert.Equal(t, time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), ws[1].Start)
+	assert.Equal(t, time.Date(2024, 1, 12, 17, 0, 0, 0, time.UTC), ws[1].End)
+
+	// repeated openings join the open window, open since the Saturday after
+	// the previous close
+	open := Scheduler().WithLoc(time.UTC).Hour(At(9)).Minute(At(0)).Second(At(0))
+	close := Scheduler().WithLoc(time.UTC).DayOfWeek(At(time.Friday)).Hour(At(17)).Minute(At(0)).Second(At(0))
+	ws = NewWindowSchedule(open, close).Windows(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ws, 2)
+	assert.Equal(t, time.Date(2023, 12, 30, 9, 0, 0, 0, time.UTC), ws[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC), ws[0].End)
+	assert.Equal(t, time.Date(2024, 1, 6, 9, 0, 0, 0, time.UTC), ws[1].Start)
+	assert.Equal(t, time.Date(2024, 1, 12, 17, 0, 0, 0, time.UTC), ws[1].End)
+	// the same start whatever the range starts at
+	for day := 1; day <= 5; day++ {
+		from := time.Date(2024, 1, day, 12, 0, 0, 0, time.UTC)
+		ws = NewWindowSchedule(open, close).Windows(from, from.Add(time.Hour))
+		assert.Len(t, ws, 1)
+		assert.Equal(t, time.Date(2023, 12, 30, 9, 0, 0, 0, time.UTC), ws[0].Start)
+	}
+
+	// never closed yet, open since the first opening
+	everyMinute := Scheduler().WithLoc(time.UTC).Second(At(0))
+	close = Scheduler().WithLoc(time.UTC).Year(At(2030)).Month(At(time.January)).Day(At(1)).Hour(At(0)).Minute(At(0)).Second(At(0))
+	for _, from := range []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)} {
+		ws = NewWindowSchedule(everyMinute, close).Windows(from, from.Add(time.Hour))
+		assert.Len(t, ws, 1)
+		assert.Equal(t, time.Unix(0, 0).UTC(), ws[0].Start)
+		assert.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), ws[0].End)
+	}
+	start := time.Date(2023, 6, 1, 12, 0, 30, 0, time.UTC)
+	ws = NewWindowSchedule(Scheduler().WithLoc(time.UTC).Second(At(0)).StartAt(&start), close).
+		Windows(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ws, 1)
+	assert.Equal(t, time.Date(2023, 6, 1, 12, 1, 0, 0, time.UTC), ws[0].Start)
+
+	// never closing
+	close = Scheduler().WithLoc(time.UTC).Year(At(2023))
+	ws = NewWindowSchedule(open, close).Windows(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	assert.Len(t, ws, 1)
+	assert.Equal(t, forever, ws[0].End)
+}
+
+func TestWindowSchedule_NextTransition(t *testing.T) {
+	w := weekOpening()
+	tr := w.NextTransition(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, &Transition{Time: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), Open: true, Schedule: w.Open}, tr)
+	tr = w.NextTransition(tr.Time)
+	assert.Equal(t, &Transition{Time: time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC), Open: false, Schedule: w.Close}, tr)
+	tr = w.NextTransition(tr.Time)
+	assert.Equal(t, &Transition{Time: time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC), Open: true, Schedule: w.Open}, tr)
+
+	// from sunset until sunrise
+	night := NewWindowSchedule(
+		SolarScheduler(Sunset, 51.5074, -0.1278, 0).WithLoc(time.UTC),
+		SolarScheduler(Sunrise, 51.5074, -0.1278, 0).WithLoc(time.UTC),
+	)
+	assert.True(t, night.InProgress(time.Date(2024, 6, 21, 23, 0, 0, 0, time.UTC)))
+	assert.False(t, night.InProgress(time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC)))
+	tr = night.NextTransition(time.Date(2024, 6, 21, 23, 0, 0, 0, time.UTC))
+	assert.False(t, tr.Open)
+	assertNear(t, time.Date(2024, 6, 22, 3, 43, 0, 0, time.UTC), &tr.Time)
+}
+
+func TestWindowSchedule_JSON(t *testing.T) {
+	w := weekOpening()
+	w.Enable = true
+	data, err := json.Marshal(w)
+	assert.NoError(t, err)
+	fromJSON, err := WindowScheduleFromJSON(string(data))
+	assert.NoError(t, err)
+	assert.True(t, fromJSON.Enable)
+	assert.Equal(t, w.String(), fromJSON.String())
+	at := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, w.NextTransition(at), fromJSON.NextTransition(at))
+	assert.Equal(t, "from at Monday, at 9th hour, at 0th minute, at 0th second until at Friday, at 17th hour, at 0th minute, at 0th second", w.String())
+
+	_, err = WindowScheduleFromJSON(`{"open":{}}`)
+	assert.Error(t, err)
+
+	// unknown locations
+	typo := `{"open":{"location":"UTC"},"close":{"location":"Asia/HoChiMinh"}}`
+	_, err = WindowScheduleFromJSON(typo, StrictLocation())
+	assert.Error(t, err)
+	_, err = WindowScheduleFromJSON(`{"open":{"location":"Asia/HoChiMinh"},"close":{"location":"UTC"}}`, StrictLocation())
+	assert.Error(t, err)
+	fromJSON, err = WindowScheduleFromJSON(typo)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Local, fromJSON.Close.Loc)
+	fromJSON, err = WindowScheduleFromJSON(`{"open":{"location":"UTC"},"close":{"location":"Asia/Ho_Chi_Minh"}}`, StrictLocation())
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Ho_Chi_Minh", fromJSON.Close.Location)
+}